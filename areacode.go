@@ -0,0 +1,51 @@
+package phonenumber
+
+import "strings"
+
+// geographicAreaCodes contains the area codes of the national numbers by country,
+// some of the countries have variable-length area codes.
+var geographicAreaCodes = map[string][]string{
+	"DE": {
+		"30", "40", "69", "89", "201", "211", "221", "228", "231", "341", "351", "421", "511", "711", "911",
+	},
+	"GB": {
+		"20", "23", "24", "28", "29", "113", "114", "115", "116", "117", "118", "121", "131", "141", "151",
+		"161", "191", "1223", "1865",
+	},
+}
+
+// populateAreaCodes sets the area codes of the countries
+func populateAreaCodes() {
+	for k, i := range iso3166Datas {
+		switch i.Alpha2 {
+		case "US", "CA":
+			// The mobile prefixes of US and Canada are their area codes
+			iso3166Datas[k].AreaCodes = i.MobileBeginWith
+		default:
+			if areaCodes, exists := nanpAreaCodes[i.Alpha2]; exists {
+				iso3166Datas[k].AreaCodes = areaCodes
+			} else if areaCodes, exists := geographicAreaCodes[i.Alpha2]; exists {
+				iso3166Datas[k].AreaCodes = areaCodes
+			}
+		}
+	}
+}
+
+// GetAreaCode parses the number by country and returns its area code.
+// An empty string is returned for invalid numbers, for numbers without
+// geographic area code (e.g. mobile) and for countries without area code data.
+func GetAreaCode(number string, country string) string {
+	parsed, iso3166 := parseInternal(number, country)
+	if !validateLandlineISO3166(parsed, iso3166) {
+		return ""
+	}
+
+	national := nationalNumber(parsed, iso3166)
+	areaCode := ""
+	for _, a := range iso3166.AreaCodes {
+		if len(a) > len(areaCode) && strings.HasPrefix(national, a) {
+			areaCode = a
+		}
+	}
+	return areaCode
+}
@@ -0,0 +1,101 @@
+package phonenumber
+
+import "strings"
+
+// AsYouTypeFormatter formats a number incrementally, as the user types each digit
+type AsYouTypeFormatter struct {
+	iso3166       ISO3166
+	digits        string
+	international bool
+}
+
+// NewAsYouTypeFormatter creates a formatter for numbers of the country
+func NewAsYouTypeFormatter(country string) *AsYouTypeFormatter {
+	return &AsYouTypeFormatter{iso3166: getISO3166ByCountry(country)}
+}
+
+// InputDigit adds the digit to the number and returns the formatted partial number so far.
+// A leading '+' switches to the international format, any other non-digit character is ignored.
+func (f *AsYouTypeFormatter) InputDigit(d rune) string {
+	switch {
+	case d == '+' && f.digits == "":
+		f.international = true
+	case d >= '0' && d <= '9':
+		f.digits += string(d)
+	}
+	return f.format()
+}
+
+// Clear resets the formatter, so a new number can be entered
+func (f *AsYouTypeFormatter) Clear() {
+	f.digits = ""
+	f.international = false
+}
+
+func (f *AsYouTypeFormatter) format() string {
+	if f.international {
+		return f.formatInternational()
+	}
+	if f.digits == "" {
+		return ""
+	}
+
+	// National numbers are typed with the trunk prefix, which is a part of the national pattern
+	for _, format := range numberFormats[f.iso3166.CountryCode] {
+		trunkPrefix := strings.TrimRight(format.national[:strings.IndexByte(format.national, '#')], " -()")
+		national := strings.TrimPrefix(f.digits, trunkPrefix)
+		if len(trunkPrefix) > 0 && national == f.digits {
+			continue
+		}
+		if national == "" {
+			return f.digits
+		}
+		if isPartialMatch(national, format) {
+			return applyPattern(format.national, national)
+		}
+	}
+	for _, format := range numberFormats[f.iso3166.CountryCode] {
+		if isPartialMatch(f.digits, format) {
+			return applyPattern(format.international, f.digits)
+		}
+	}
+	return groupDigits(f.digits)
+}
+
+func (f *AsYouTypeFormatter) formatInternational() string {
+	countryCode := f.iso3166.CountryCode
+	if countryCode == "" || !strings.HasPrefix(f.digits, countryCode) {
+		return "+" + f.digits
+	}
+
+	national := strings.TrimPrefix(f.digits, countryCode)
+	if national == "" {
+		return "+" + countryCode
+	}
+	for _, format := range numberFormats[countryCode] {
+		if isPartialMatch(national, format) {
+			return "+" + countryCode + " " + applyPattern(format.international, national)
+		}
+	}
+	return "+" + countryCode + " " + groupDigits(national)
+}
+
+// isPartialMatch reports whether the partial national number may become a number of the format
+func isPartialMatch(national string, format numberFormat) bool {
+	if len(national) > strings.Count(format.national, "#") {
+		return false
+	}
+	return strings.HasPrefix(national, format.leadingDigits) || strings.HasPrefix(format.leadingDigits, national)
+}
+
+// groupDigits splits the digits into groups of three, used when there is no matching format
+func groupDigits(digits string) string {
+	var b strings.Builder
+	for k := 0; k < len(digits); k++ {
+		if k > 0 && k%3 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteByte(digits[k])
+	}
+	return b.String()
+}
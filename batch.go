@@ -0,0 +1,190 @@
+package phonenumber
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"strings"
+)
+
+// ParseBatch is Parse mobile numbers by country. The country is resolved once
+// for all the numbers. The result is aligned index-for-index with the input,
+// invalid numbers are returned as empty strings.
+func ParseBatch(numbers []string, country string) []string {
+	return ParseAll(numbers, country)
+}
+
+// ParseAll is ParseBatch for any string-like type, e.g. `type E164 string`
+func ParseAll[T ~string](numbers []T, country string) []string {
+	parse := countryParser(country)
+	result := make([]string, len(numbers))
+	for k, number := range numbers {
+		result[k] = parseMobile(parse, string(number))
+	}
+	return result
+}
+
+// parseMobile parses the number by the country parser and notifies the observer,
+// an empty string is returned for invalid numbers
+func parseMobile(parse func(number string) (string, ISO3166), number string) string {
+	parsed, iso3166 := parse(number)
+	valid := validateMobileISO3166(parsed, iso3166)
+	observeParse(iso3166, valid, valid)
+	if valid {
+		return parsed
+	}
+	return ""
+}
+
+// contextCheckInterval is how many numbers are parsed between the context checks
+const contextCheckInterval = 256
+
+// ParseBatchContext is ParseBatch, which stops early when the context is done.
+// On cancellation the numbers parsed so far are returned together with the context error.
+func ParseBatchContext(ctx context.Context, numbers []string, country string) ([]string, error) {
+	parse := countryParser(country)
+	result := make([]string, len(numbers))
+	for k, number := range numbers {
+		if k%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return result[:k], err
+			}
+		}
+		result[k] = parseMobile(parse, number)
+	}
+	return result, nil
+}
+
+// ParseBatchWithFlags parses the numbers by country like ParseWithFlags.
+// The country is resolved once for all the numbers.
+// The result is aligned index-for-index with the input.
+func ParseBatchWithFlags(numbers []string, country string) []ParseResult {
+	parse := countryParser(country)
+	result := make([]ParseResult, len(numbers))
+	for k, number := range numbers {
+		result[k] = newParseResult(parse(number))
+		result[k].RawInput = number
+		observeParse(result[k].ISO3166, result[k].Valid, result[k].Mobile)
+	}
+	return result
+}
+
+// ParseReader is Parse mobile numbers by country, reading one number per line.
+// The country is resolved once for all the numbers. The callback is invoked
+// for every line, including blank ones, with the parsed number and whether it is valid.
+func ParseReader(r io.Reader, country string, fn func(line string, parsed string, valid bool)) error {
+	return ParseReaderSize(r, country, bufio.MaxScanTokenSize, fn)
+}
+
+// ParseReaderSize is ParseReader with the maximum size of a line in bytes
+func ParseReaderSize(r io.Reader, country string, maxLineSize int, fn func(line string, parsed string, valid bool)) error {
+	return parseReader(context.Background(), r, country, maxLineSize, fn)
+}
+
+// ParseReaderContext is ParseReader, which stops reading when the context is done
+// and returns the context error.
+func ParseReaderContext(ctx context.Context, r io.Reader, country string, fn func(line string, parsed string, valid bool)) error {
+	return parseReader(ctx, r, country, bufio.MaxScanTokenSize, fn)
+}
+
+func parseReader(ctx context.Context, r io.Reader, country string, maxLineSize int, fn func(line string, parsed string, valid bool)) error {
+	parse := countryParser(country)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(maxLineSize, 4096)), maxLineSize)
+	for k := 0; scanner.Scan(); k++ {
+		if k%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			fn(line, "", false)
+			continue
+		}
+
+		parsed := parseMobile(parse, line)
+		fn(line, parsed, parsed != "")
+	}
+	return scanner.Err()
+}
+
+// Report summarizes the validation of the numbers by ValidateReport
+type Report struct {
+	Total    int
+	Valid    int
+	Mobile   int
+	Landline int
+	Invalid  int
+	// InvalidNumbers are the invalid inputs in the input order
+	InvalidNumbers []InvalidNumber
+}
+
+// InvalidNumber is an invalid input of ValidateReport with the reasons given by Diagnose
+type InvalidNumber struct {
+	Index   int
+	Input   string
+	Reasons []string
+}
+
+// ValidateReport parses the mobile and landline numbers by country and counts them by outcome,
+// e.g. for the import summaries. The country is resolved once for all the numbers.
+func ValidateReport(numbers []string, country string) Report {
+	parse := countryParser(country)
+	report := Report{Total: len(numbers), InvalidNumbers: []InvalidNumber{}}
+	for k, number := range numbers {
+		parsed, iso3166 := parse(number)
+		valid, mobile := validatePhoneISO3166(parsed, iso3166)
+		switch {
+		case mobile:
+			report.Mobile++
+		case valid:
+			report.Landline++
+		default:
+			report.Invalid++
+			report.InvalidNumbers = append(report.InvalidNumbers, InvalidNumber{
+				Index:   k,
+				Input:   number,
+				Reasons: diagnosePhoneISO3166(number, country, parsed, iso3166),
+			})
+		}
+	}
+	report.Valid = report.Mobile + report.Landline
+	return report
+}
+
+// ParseList is ParseWithLandLine for the numbers given in a single field, e.g. of a contact,
+// separated by ';' or ','. A '/' separates the numbers only when every part of it is a valid
+// number on its own, as it is a part of the number otherwise, e.g. 0171/1234567.
+// The invalid numbers are dropped.
+func ParseList(field string, country string) []string {
+	parse := countryParser(country)
+	result := []string{}
+	for _, part := range strings.FieldsFunc(field, func(r rune) bool { return r == ';' || r == ',' }) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		numbers := []string{part}
+		if slashed := strings.Split(part, "/"); len(slashed) > 1 {
+			numbers = slashed
+			for _, number := range slashed {
+				if parsed, iso3166 := parse(number); !validateLandlineISO3166(parsed, iso3166) {
+					numbers = []string{part}
+					break
+				}
+			}
+		}
+
+		for _, number := range numbers {
+			parsed, iso3166 := parse(number)
+			valid := validateLandlineISO3166(parsed, iso3166)
+			observeLandline(parsed, iso3166, valid)
+			if valid {
+				result = append(result, parsed)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,195 @@
+package phonenumber
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBatch(t *testing.T) {
+	for _, country := range []string{"LV", "JP", "USA", "", "XXXK"} {
+		numbers := []string{}
+		for _, tt := range mobWithLLFormatTests {
+			numbers = append(numbers, tt.input)
+		}
+
+		parsed := ParseBatch(numbers, country)
+		if len(parsed) != len(numbers) {
+			t.Fatalf("ParseBatch(country=`%s`): expected %d results, actual %d", country, len(numbers), len(parsed))
+		}
+		for k, number := range numbers {
+			if expected := Parse(number, country); parsed[k] != expected {
+				t.Errorf("ParseBatch(number=`%s`, country=`%s`): expected `%s`, actual `%s`", number, country, expected, parsed[k])
+			}
+		}
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	type E164 string
+	numbers := []E164{}
+	for _, tt := range mobWithLLFormatTests {
+		numbers = append(numbers, E164(tt.input))
+	}
+
+	parsed := ParseAll(numbers, "LV")
+	if len(parsed) != len(numbers) {
+		t.Fatalf("ParseAll(country=`LV`): expected %d results, actual %d", len(numbers), len(parsed))
+	}
+	for k, number := range numbers {
+		if expected := Parse(string(number), "LV"); parsed[k] != expected {
+			t.Errorf("ParseAll(number=`%s`, country=`LV`): expected `%s`, actual `%s`", number, expected, parsed[k])
+		}
+	}
+}
+
+func TestParseBatchContext(t *testing.T) {
+	numbers := make([]string, 3*contextCheckInterval)
+	for k := range numbers {
+		numbers[k] = "+371 25 641 580"
+	}
+
+	parsed, err := ParseBatchContext(context.Background(), numbers, "LV")
+	if err != nil || len(parsed) != len(numbers) || parsed[len(parsed)-1] != "37125641580" {
+		t.Errorf("ParseBatchContext(): expected %d parsed numbers, actual %d, error `%v`", len(numbers), len(parsed), err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	parsed, err = ParseBatchContext(ctx, numbers, "LV")
+	if !errors.Is(err, context.Canceled) || len(parsed) != 0 {
+		t.Errorf("ParseBatchContext(cancelled): expected error `%v` and no numbers, actual error `%v` and %d numbers", context.Canceled, err, len(parsed))
+	}
+}
+
+func TestParseReaderContext(t *testing.T) {
+	input := strings.Repeat("+371 25 641 580\n", 3*contextCheckInterval)
+
+	lines := 0
+	err := ParseReaderContext(context.Background(), strings.NewReader(input), "LV", func(string, string, bool) { lines++ })
+	if err != nil || lines != 3*contextCheckInterval {
+		t.Errorf("ParseReaderContext(): expected %d lines, actual %d, error `%v`", 3*contextCheckInterval, lines, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lines = 0
+	err = ParseReaderContext(ctx, strings.NewReader(input), "LV", func(string, string, bool) {
+		lines++
+		cancel()
+	})
+	if !errors.Is(err, context.Canceled) || lines != contextCheckInterval {
+		t.Errorf("ParseReaderContext(cancelled): expected error `%v` after %d lines, actual error `%v` after %d lines", context.Canceled, contextCheckInterval, err, lines)
+	}
+}
+
+func TestParseBatchWithFlags(t *testing.T) {
+	for _, tt := range mobWithLLFormatTests {
+		results := ParseBatchWithFlags([]string{tt.input}, tt.country)
+		if len(results) != 1 {
+			t.Fatalf("ParseBatchWithFlags(number=`%s`, country=`%s`): expected 1 result, actual %d", tt.input, tt.country, len(results))
+		}
+		if results[0].E164 != tt.expected || results[0].Valid != tt.valid || results[0].Mobile != tt.mobile {
+			t.Errorf("ParseBatchWithFlags(number=`%s`, country=`%s`): expected (`%s`, %t, %t), actual (`%s`, %t, %t)", tt.input, tt.country, tt.expected, tt.valid, tt.mobile, results[0].E164, results[0].Valid, results[0].Mobile)
+		}
+	}
+}
+
+func TestParseReader(t *testing.T) {
+	input := "+371 25 641 580\n\n+371 (67) 881-727\r\n25641580\n"
+	expected := []struct {
+		line   string
+		parsed string
+		valid  bool
+	}{
+		{"+371 25 641 580", "37125641580", true},
+		{"", "", false},
+		{"+371 (67) 881-727", "", false},
+		{"25641580", "37125641580", true},
+	}
+
+	k := 0
+	err := ParseReader(strings.NewReader(input), "LV", func(line string, parsed string, valid bool) {
+		if k >= len(expected) {
+			t.Fatalf("ParseReader(): unexpected line `%s`", line)
+		}
+		if line != expected[k].line || parsed != expected[k].parsed || valid != expected[k].valid {
+			t.Errorf("ParseReader(): expected (`%s`, `%s`, %t), actual (`%s`, `%s`, %t)", expected[k].line, expected[k].parsed, expected[k].valid, line, parsed, valid)
+		}
+		k++
+	})
+	if err != nil {
+		t.Errorf("ParseReader(): unexpected error `%v`", err)
+	}
+	if k != len(expected) {
+		t.Errorf("ParseReader(): expected %d lines, actual %d", len(expected), k)
+	}
+}
+
+func TestParseReaderSize(t *testing.T) {
+	long := strings.Repeat(" ", 100) + "+371 25 641 580"
+	if err := ParseReaderSize(strings.NewReader(long), "LV", 10, func(string, string, bool) {}); !errors.Is(err, bufio.ErrTooLong) {
+		t.Errorf("ParseReaderSize(maxLineSize=10): expected error `%v`, actual `%v`", bufio.ErrTooLong, err)
+	}
+
+	parsed := ""
+	if err := ParseReaderSize(strings.NewReader(long), "LV", 1024, func(_ string, p string, _ bool) { parsed = p }); err != nil {
+		t.Errorf("ParseReaderSize(maxLineSize=1024): unexpected error `%v`", err)
+	}
+	if parsed != "37125641580" {
+		t.Errorf("ParseReaderSize(maxLineSize=1024): expected `37125641580`, actual `%s`", parsed)
+	}
+}
+
+func TestValidateReport(t *testing.T) {
+	numbers := []string{"+371 25 641 580", "+371 (67) 881-727", "25641580", "2564158", "", "67881727", "6788"}
+	report := ValidateReport(numbers, "LV")
+	if report.Total != 7 || report.Valid != 4 || report.Mobile != 2 || report.Landline != 2 || report.Invalid != 3 {
+		t.Errorf("ValidateReport(country=`LV`): expected counts (7, 4, 2, 2, 3), actual (%d, %d, %d, %d, %d)", report.Total, report.Valid, report.Mobile, report.Landline, report.Invalid)
+	}
+
+	expected := []InvalidNumber{
+		{Index: 3, Input: "2564158", Reasons: []string{"length 7 not in [8]"}},
+		{Index: 4, Input: "", Reasons: []string{"no digits in the number"}},
+		{Index: 6, Input: "6788", Reasons: []string{"length 4 not in [8]"}},
+	}
+	if !reflect.DeepEqual(report.InvalidNumbers, expected) {
+		t.Errorf("ValidateReport(country=`LV`): expected invalid numbers `%v`, actual `%v`", expected, report.InvalidNumbers)
+	}
+
+	// the landlines are diagnosed by the fixed line lengths too
+	report = ValidateReport([]string{"+86 21 1234 567"}, "CN")
+	if expected := []string{"length 9 not in [10,11]"}; report.Invalid != 1 || !reflect.DeepEqual(report.InvalidNumbers[0].Reasons, expected) {
+		t.Errorf("ValidateReport(country=`CN`): expected reasons `%q`, actual `%+v`", expected, report.InvalidNumbers)
+	}
+
+	report = ValidateReport(numbers, "XX")
+	if report.Invalid != 7 || len(report.InvalidNumbers) != 7 || report.InvalidNumbers[0].Reasons[0] != "unknown country 'XX'" {
+		t.Errorf("ValidateReport(country=`XX`): expected all 7 numbers invalid for unknown country, actual `%+v`", report)
+	}
+}
+
+// Parse the numbers of a single field
+var listTests = []struct {
+	input    string
+	country  string
+	expected []string
+}{
+	{"+371 25 641 580; +371 67 881 727", "LV", []string{"37125641580", "37167881727"}},
+	{"25641580, 2564158,67881727", "LV", []string{"37125641580", "37167881727"}},
+	{"25641580 / 67881727", "LV", []string{"37125641580", "37167881727"}},
+	{"0171/1234567", "DE", []string{"491711234567"}},
+	{"0171/1234567; 030/1234567", "DE", []string{"491711234567", "49301234567"}},
+	{" ; ,, ", "LV", []string{}},
+	{"", "LV", []string{}},
+}
+
+func TestParseList(t *testing.T) {
+	for _, tt := range listTests {
+		if numbers := ParseList(tt.input, tt.country); !reflect.DeepEqual(numbers, tt.expected) {
+			t.Errorf("ParseList(field=`%s`, country=`%s`): expected `%v`, actual `%v`", tt.input, tt.country, tt.expected, numbers)
+		}
+	}
+}
@@ -1,6 +1,10 @@
 package phonenumber
 
-import "testing"
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
 
 func BenchmarkParseWithLandLine(b *testing.B) {
 	benchmarks := []struct {
@@ -24,3 +28,135 @@ func BenchmarkParseWithLandLine(b *testing.B) {
 		})
 	}
 }
+
+var parseBenchmarks = []struct {
+	number  string
+	country string
+}{
+	{"+371 25 641 580", "LV"},
+	{"(817) 569-8900", "US"},
+	{"090 6135 3368", "JP"},
+	{"8 916 123-45-67", "RU"},
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, bm := range parseBenchmarks {
+		b.Run(bm.number, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				Parse(bm.number, bm.country)
+			}
+		})
+	}
+}
+
+func BenchmarkParseInto(b *testing.B) {
+	dst := make([]byte, 20)
+	for _, bm := range parseBenchmarks {
+		b.Run(bm.number, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ParseInto(dst, bm.number, bm.country)
+			}
+		})
+	}
+}
+
+func BenchmarkNormalize(b *testing.B) {
+	digitsOnlyRegexp := regexp.MustCompile(`\D`)
+	for _, bm := range parseBenchmarks {
+		b.Run("regexp/"+bm.number, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				digitsOnlyRegexp.ReplaceAllString(bm.number, "")
+			}
+		})
+		b.Run("scan/"+bm.number, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Normalize(bm.number)
+			}
+		})
+	}
+}
+
+// detectCountryLinear is DetectCountry scanning the whole table, as it was before the country code trie
+func detectCountryLinear(e164 string) (ISO3166, bool) {
+	number := Normalize(e164)
+
+	var mobileMatches, landlineMatches []ISO3166
+	for _, i := range GetISO3166() {
+		valid, mobile := validatePhoneISO3166(number, i)
+		if mobile {
+			mobileMatches = append(mobileMatches, i)
+		} else if valid && matchesNANPAreaCode(number, i) {
+			landlineMatches = append(landlineMatches, i)
+		}
+	}
+
+	if len(mobileMatches) > 0 {
+		return mobileMatches[0], len(mobileMatches) == 1
+	}
+	if len(landlineMatches) > 0 {
+		return landlineMatches[0], len(landlineMatches) == 1
+	}
+	return ISO3166{}, false
+}
+
+func BenchmarkDetectCountry(b *testing.B) {
+	WarmCache()
+	for _, number := range []string{"+37125641580", "+12025550143", "+819061353368"} {
+		b.Run("linear/"+number, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				detectCountryLinear(number)
+			}
+		})
+		b.Run("trie/"+number, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				DetectCountry(number)
+			}
+		})
+	}
+}
+
+// getISO3166ByCountryLinear is getISO3166ByCountry scanning the whole table, as it was before the country index
+func getISO3166ByCountryLinear(country string) ISO3166 {
+	country = strings.ToUpper(stripSpaces(country))
+	if country == "" {
+		return ISO3166{}
+	}
+
+	datas := GetISO3166()
+	for _, key := range []func(i ISO3166) string{
+		func(i ISO3166) string { return i.Alpha2 },
+		func(i ISO3166) string { return i.Alpha3 },
+		func(i ISO3166) string { return i.CountryName },
+	} {
+		for _, i := range datas {
+			if strings.ToUpper(stripSpaces(key(i))) == country {
+				return i
+			}
+		}
+	}
+	for alias, alpha2 := range countryAliases {
+		if strings.ToUpper(stripSpaces(alias)) == country {
+			return getISO3166ByCountryLinear(alpha2)
+		}
+	}
+	return ISO3166{}
+}
+
+func BenchmarkGetISO3166ByCountry(b *testing.B) {
+	for _, country := range []string{"LV", "ZW", "ZWE", "Zimbabwe", "Holland"} {
+		b.Run("linear/"+country, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				getISO3166ByCountryLinear(country)
+			}
+		})
+		b.Run("index/"+country, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				getISO3166ByCountry(country)
+			}
+		})
+	}
+}
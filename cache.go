@@ -0,0 +1,76 @@
+package phonenumber
+
+import (
+	"container/list"
+	"sync"
+)
+
+// CachingParser is Parse with a least recently used cache of the results,
+// for the workloads parsing the same numbers again and again.
+// It is safe for concurrent use. The cache hits are not reported to the Observer.
+// The cache is cleared when the countries or the default country change.
+type CachingParser struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	items   map[cacheKey]*list.Element
+	version uint64
+}
+
+type cacheKey struct {
+	number  string
+	country string
+}
+
+type cacheEntry struct {
+	key    cacheKey
+	parsed string
+}
+
+// NewCachingParser returns a parser caching up to size results, at least one
+func NewCachingParser(size int) *CachingParser {
+	return &CachingParser{
+		size:  max(size, 1),
+		order: list.New(),
+		items: map[cacheKey]*list.Element{},
+	}
+}
+
+// Parse is Parse mobile number by country, returning the cached result for the known numbers
+func (p *CachingParser) Parse(number string, country string) string {
+	key := cacheKey{number, country}
+	version := iso3166Version.Load()
+	p.mu.Lock()
+	if version != p.version {
+		p.order.Init()
+		clear(p.items)
+		p.version = version
+	}
+	if e, exists := p.items[key]; exists {
+		p.order.MoveToFront(e)
+		p.mu.Unlock()
+		return e.Value.(*cacheEntry).parsed
+	}
+	p.mu.Unlock()
+
+	// the number is parsed unlocked, so a concurrent miss may parse it twice
+	parsed := Parse(number, country)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// the countries changed while the number was parsed, the result is not cached
+	if version != p.version || version != iso3166Version.Load() {
+		return parsed
+	}
+	if e, exists := p.items[key]; exists {
+		p.order.MoveToFront(e)
+		return parsed
+	}
+	p.items[key] = p.order.PushFront(&cacheEntry{key, parsed})
+	if p.order.Len() > p.size {
+		oldest := p.order.Back()
+		p.order.Remove(oldest)
+		delete(p.items, oldest.Value.(*cacheEntry).key)
+	}
+	return parsed
+}
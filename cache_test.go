@@ -0,0 +1,69 @@
+package phonenumber
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCachingParser(t *testing.T) {
+	p := NewCachingParser(2)
+	for _, tt := range mobWithLLFormatTests {
+		if number, expected := p.Parse(tt.input, tt.country), Parse(tt.input, tt.country); number != expected {
+			t.Errorf("CachingParser.Parse(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, expected, number)
+		}
+		if len(p.items) > 2 || p.order.Len() != len(p.items) {
+			t.Fatalf("CachingParser.Parse(number=`%s`, country=`%s`): expected at most 2 cached results, actual %d", tt.input, tt.country, len(p.items))
+		}
+	}
+
+	p = NewCachingParser(2)
+	p.Parse("+371 25 641 580", "LV")
+	p.Parse("25 641 580", "LV")
+	p.Parse("+371 25 641 580", "LV")
+	p.Parse("(817) 569-8900", "US")
+	if _, exists := p.items[cacheKey{"25 641 580", "LV"}]; exists {
+		t.Errorf("CachingParser.Parse(): expected the least recently used `25 641 580` to be evicted")
+	}
+	if _, exists := p.items[cacheKey{"+371 25 641 580", "LV"}]; !exists {
+		t.Errorf("CachingParser.Parse(): expected the recently used `+371 25 641 580` to be cached")
+	}
+}
+
+func TestCachingParserConcurrent(t *testing.T) {
+	p := NewCachingParser(4)
+	var wg sync.WaitGroup
+	for k := 0; k < 8; k++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, tt := range mobWithLLFormatTests[:10] {
+				if number, expected := p.Parse(tt.input, tt.country), Parse(tt.input, tt.country); number != expected {
+					t.Errorf("CachingParser.Parse(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, expected, number)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestCachingParserStale(t *testing.T) {
+	p := NewCachingParser(4)
+	if number := p.Parse("25 641 580", ""); number != "" {
+		t.Fatalf("CachingParser.Parse(number=`25 641 580`, country=``): expected `` without default country, actual `%s`", number)
+	}
+	SetDefaultCountry("LV")
+	defer SetDefaultCountry("")
+	if number := p.Parse("25 641 580", ""); number != "37125641580" {
+		t.Errorf("CachingParser.Parse(number=`25 641 580`, country=``): expected `37125641580` after the default country is set, actual `%s`", number)
+	}
+
+	keepISO3166(t)
+	lv := getISO3166ByCountry("LV")
+	lv.MobileBeginWith = []string{"6"}
+	if err := OverrideCountry("LV", lv); err != nil {
+		t.Fatalf("OverrideCountry(LV): unexpected error `%v`", err)
+	}
+	if number := p.Parse("25 641 580", ""); number != "" {
+		t.Errorf("CachingParser.Parse(number=`25 641 580`, country=``): expected `` after LV is overridden, actual `%s`", number)
+	}
+}
@@ -0,0 +1,50 @@
+package phonenumber
+
+import "strings"
+
+// Capability tells whether the numbers can receive SMS and voice calls
+type Capability struct {
+	SMS   bool `json:"sms"`
+	Voice bool `json:"voice"`
+}
+
+// numberCapabilities contains the capabilities by the national number prefix,
+// for the ranges which differ from the mobile and landline defaults
+var numberCapabilities = map[string]map[string]Capability{
+	// Personal numbers forward the calls only
+	"GB": {"70": {SMS: false, Voice: true}},
+	// Pagers and machine-to-machine numbers
+	"NL": {"66": {SMS: true, Voice: false}, "97": {SMS: true, Voice: false}},
+}
+
+// populateCapabilities sets the number capabilities of the countries
+func populateCapabilities() {
+	for k, i := range iso3166Datas {
+		if capabilities, exists := numberCapabilities[i.Alpha2]; exists {
+			iso3166Datas[k].Capabilities = capabilities
+		}
+	}
+}
+
+// Capabilities parses the number by country and returns whether it can receive SMS and voice calls.
+// Without the capability data of the number range, mobile numbers support both and landline
+// numbers support voice only. Invalid numbers support neither.
+func Capabilities(number string, country string) (sms bool, voice bool) {
+	parsed, iso3166 := parseInternal(number, country)
+	valid, mobile := validatePhoneISO3166(parsed, iso3166)
+	if !valid {
+		return false, false
+	}
+
+	national := nationalNumber(parsed, iso3166)
+	prefix := ""
+	for p := range iso3166.Capabilities {
+		if len(p) > len(prefix) && strings.HasPrefix(national, p) {
+			prefix = p
+		}
+	}
+	if capability, exists := iso3166.Capabilities[prefix]; exists {
+		return capability.SMS, capability.Voice
+	}
+	return mobile, true
+}
@@ -0,0 +1,58 @@
+package phonenumber
+
+import (
+	"maps"
+	"strings"
+)
+
+// mobileCarriers contains the carrier names by the national mobile number prefix,
+// for the countries where the prefixes map to a single carrier
+var mobileCarriers = map[string]map[string]string{
+	"BY": {"25": "life:)", "33": "MTS", "44": "A1"},
+	"KZ": {"700": "Kcell", "701": "Kcell", "705": "Beeline", "707": "Tele2", "747": "Tele2", "771": "Beeline", "775": "Kcell", "776": "Beeline", "777": "Beeline", "778": "Kcell"},
+	"UA": {"39": "Kyivstar", "50": "Vodafone", "63": "lifecell", "66": "Vodafone", "67": "Kyivstar", "68": "Kyivstar", "73": "lifecell", "93": "lifecell", "95": "Vodafone", "96": "Kyivstar", "97": "Kyivstar", "98": "Kyivstar", "99": "Vodafone"},
+}
+
+// populateCarriers sets the mobile carriers of the countries
+func populateCarriers() {
+	for k, i := range iso3166Datas {
+		if carriers, exists := mobileCarriers[i.Alpha2]; exists {
+			iso3166Datas[k].Carriers = carriers
+		}
+	}
+}
+
+// GetCarrier parses the mobile number by country and returns its carrier name.
+// An empty string is returned for invalid and landline numbers, and when the carrier is not known.
+func GetCarrier(number string, country string) string {
+	parsed, iso3166 := parseInternal(number, country)
+	if !validateMobileISO3166(parsed, iso3166) {
+		return ""
+	}
+
+	national := nationalNumber(parsed, iso3166)
+	prefix := ""
+	for p := range iso3166.Carriers {
+		if len(p) > len(prefix) && strings.HasPrefix(national, p) {
+			prefix = p
+		}
+	}
+	return iso3166.Carriers[prefix]
+}
+
+// SetCarriers replaces the mobile carriers of the country, keyed by the national number prefix.
+// It allows to keep the carriers up to date without waiting for a release.
+func SetCarriers(alpha2 string, carriers map[string]string) error {
+	iso3166Once.Do(loadISO3166)
+	iso3166Lock.Lock()
+	defer iso3166Lock.Unlock()
+	k := indexOfAlpha2(alpha2, iso3166Datas)
+	if k == -1 {
+		return ErrUnknownCountry
+	}
+
+	datas := copyCountries(iso3166Datas, 0)
+	datas[k].Carriers = maps.Clone(carriers)
+	iso3166Datas = datas
+	return nil
+}
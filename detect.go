@@ -0,0 +1,88 @@
+package phonenumber
+
+import "strings"
+
+// DetectCountry returns the country of an international number, e.g. +12025550143.
+// Countries where the number matches a mobile prefix (for NANP countries, an area code)
+// are preferred. The flag reports whether the best match is unique.
+// The numbers of the global services, e.g. +800, get their NonGeographic pseudo-country.
+func DetectCountry(e164 string) (ISO3166, bool) {
+	number := Normalize(e164)
+	if iso3166, unique := detectCountry(number, getISO3166ByNumberPrefix(number)); !iso3166.IsZero() {
+		return iso3166, unique
+	}
+	if iso3166 := getNonGeographicByNumber(number); !iso3166.IsZero() {
+		return iso3166, true
+	}
+	return ISO3166{}, false
+}
+
+// DetectCountryAmong is DetectCountry limited to the allowed countries given as alpha2,
+// e.g. the markets the numbers are expected from. An empty list allows all the countries.
+func DetectCountryAmong(e164 string, allowed []string) (ISO3166, bool) {
+	if len(allowed) == 0 {
+		return DetectCountry(e164)
+	}
+
+	number := Normalize(e164)
+	candidates := []ISO3166{}
+	for _, i := range getISO3166ByNumberPrefix(number) {
+		for _, alpha2 := range allowed {
+			if strings.EqualFold(stripSpaces(alpha2), i.Alpha2) {
+				candidates = append(candidates, i)
+				break
+			}
+		}
+	}
+	return detectCountry(number, candidates)
+}
+
+// detectCountry is DetectCountry for the normalized number among the candidate countries
+func detectCountry(number string, candidates []ISO3166) (ISO3166, bool) {
+	var mobileMatches, landlineMatches []ISO3166
+	for _, i := range candidates {
+		valid, mobile := validatePhoneISO3166(number, i)
+		if mobile {
+			mobileMatches = append(mobileMatches, i)
+		} else if valid && matchesNANPAreaCode(number, i) {
+			landlineMatches = append(landlineMatches, i)
+		}
+	}
+
+	if len(mobileMatches) > 0 {
+		return mobileMatches[0], len(mobileMatches) == 1
+	}
+	if len(landlineMatches) > 0 {
+		return landlineMatches[0], len(landlineMatches) == 1
+	}
+	return ISO3166{}, false
+}
+
+// CountryCodeLength returns the number of digits in the country code of the country,
+// e.g. 1 for US and 3 for LV, 0 for unknown countries
+func CountryCodeLength(alpha2 string) int {
+	return len(getISO3166ByCountry(alpha2).CountryCode)
+}
+
+// SplitCountryCode splits the international number, e.g. +37125641580, into its country code
+// and national number. The longest country code the number starts with wins, the codes
+// of the global services, e.g. +800, included. The flag is false when there is no such
+// code or nothing follows it.
+func SplitCountryCode(e164 string) (code string, rest string, ok bool) {
+	number := Normalize(e164)
+	for _, i := range getISO3166ByNumberPrefix(number) {
+		if len(i.CountryCode) > len(code) {
+			code = i.CountryCode
+		}
+	}
+	for _, i := range nonGeographicDatas {
+		if strings.HasPrefix(number, i.CountryCode) && len(i.CountryCode) > len(code) {
+			code = i.CountryCode
+		}
+	}
+
+	if code == "" || len(number) == len(code) {
+		return "", "", false
+	}
+	return code, number[len(code):], true
+}
@@ -0,0 +1,269 @@
+package phonenumber
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// Detect country of international numbers
+var detectCountryTests = []struct {
+	input    string
+	expected string
+	unique   bool
+}{
+	{"+12025550143", "US", true},
+	{"+12045550143", "CA", true},
+	{"+12645812345", "AI", true},
+	{"+18685550143", "TT", true},
+	{"+447700900000", "GB", true},
+	{"+441234567890", "GB", true},
+	{"+37167881727", "LV", true},
+	{"+819061353368", "JP", true},
+	{"819061353368", "JP", true},
+	{"+12849999999", "VG", true},
+
+	// Unknown numbers
+	{"+15555550143", "", false},
+	{"+9991234", "", false},
+	{"", "", false},
+}
+
+func TestDetectCountry(t *testing.T) {
+	for _, tt := range detectCountryTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			country, unique := DetectCountry(tt.input)
+			if country.Alpha2 != tt.expected {
+				t.Errorf("DetectCountry(number=`%s`): expected `%s`, actual `%s`", tt.input, tt.expected, country.Alpha2)
+			}
+			if unique != tt.unique {
+				t.Errorf("DetectCountry(number=`%s`): expected unique `%t`, actual `%t`", tt.input, tt.unique, unique)
+			}
+		})
+	}
+}
+
+// NANP countries by area code
+var nanpTests = []struct {
+	input    string
+	expected string
+}{
+	{"+1 202 555 0143", "US"},
+	{"2025550143", "US"},
+	{"+1 416 555 0143", "CA"},
+	{"+1 876 555 0143", "JM"},
+	{"+1 658 555 0143", "JM"},
+	{"+1 809 555 0143", "DO"},
+	{"+1 849 555 0143", "DO"},
+	{"+1 868 555 0143", "TT"},
+	{"+1 242 555 0143", "BS"},
+	{"+1 264 497 1234", "AI"},
+	{"+1 800 555 0143", ""},
+	{"+44 7700 900000", ""},
+	{"+1 202 555", ""},
+}
+
+func TestGetNANPRegion(t *testing.T) {
+	for _, tt := range nanpTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			country := GetNANPRegion(tt.input)
+			if country.Alpha2 != tt.expected {
+				t.Errorf("GetNANPRegion(number=`%s`): expected `%s`, actual `%s`", tt.input, tt.expected, country.Alpha2)
+			}
+		})
+	}
+}
+
+// NANP landline numbers are detected by area code
+var nanpLandlineTests = []struct {
+	input    string
+	expected string
+}{
+	{"12644971234", "AI"},
+	{"18095550143", "DO"},
+	{"18765550143", "JM"},
+	{"14165550143", "CA"},
+	{"12425550143", "BS"},
+}
+
+func TestGetISO3166ByNumberNANP(t *testing.T) {
+	for _, tt := range nanpLandlineTests {
+		if country := GetISO3166ByNumber(tt.input, true); country.Alpha2 != tt.expected {
+			t.Errorf("GetISO3166ByNumber(number=`%s`, withLandline=true): expected `%s`, actual `%s`", tt.input, tt.expected, country.Alpha2)
+		}
+		if country, _ := DetectCountry("+" + tt.input); country.Alpha2 != tt.expected {
+			t.Errorf("DetectCountry(number=`+%s`): expected `%s`, actual `%s`", tt.input, tt.expected, country.Alpha2)
+		}
+	}
+
+	// Every area code of the NANP members tells the country apart from the US
+	for alpha2, areaCodes := range nanpAreaCodes {
+		for _, areaCode := range areaCodes {
+			number := "1" + areaCode + "5550143"
+			if country := GetISO3166ByNumber(number, true); country.Alpha2 != alpha2 {
+				t.Errorf("GetISO3166ByNumber(number=`%s`, withLandline=true): expected `%s`, actual `%s`", number, alpha2, country.Alpha2)
+			}
+			if country, unique := DetectCountry("+" + number); country.Alpha2 != alpha2 || !unique {
+				t.Errorf("DetectCountry(number=`+%s`): expected (`%s`, true), actual (`%s`, %t)", number, alpha2, country.Alpha2, unique)
+			}
+		}
+	}
+}
+
+// Parse international numbers without country
+var e164OnlyTests = []struct {
+	input    string
+	expected string
+}{
+	{"+12025550143", "12025550143"},
+	{"+1 (204) 555-0143", "12045550143"},
+	{"+44 7700 900000", "447700900000"},
+	{"+44 (0) 7700 900000", "447700900000"},
+	{"+371 25 641 580", "37125641580"},
+	{"+81 90 6135 3368", "819061353368"},
+	{"+371 (67) 881-727", ""},
+	{"12025550143", ""},
+	{"+999 1234", ""},
+	{"", ""},
+}
+
+func TestParseE164Only(t *testing.T) {
+	for _, tt := range e164OnlyTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			number := ParseE164Only(tt.input)
+			if number != tt.expected {
+				t.Errorf("ParseE164Only(number=`%s`): expected `%s`, actual `%s`", tt.input, tt.expected, number)
+			}
+		})
+	}
+}
+
+func TestDetectCountryTrie(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, i := range GetISO3166() {
+		for _, mobile := range []bool{true, false} {
+			number := "+" + RandomNumber(i.Alpha2, mobile, rng)
+			expected, expectedUnique := detectCountryLinear(number)
+			if country, unique := DetectCountry(number); country.Alpha2 != expected.Alpha2 || unique != expectedUnique {
+				t.Errorf("DetectCountry(number=`%s`): expected (`%s`, %t), actual (`%s`, %t)", number, expected.Alpha2, expectedUnique, country.Alpha2, unique)
+			}
+		}
+	}
+
+	countries := []string{}
+	for _, i := range getISO3166ByNumberPrefix("79161234567") {
+		countries = append(countries, i.Alpha2)
+	}
+	if strings.Join(countries, ",") != "KZ,RU" {
+		t.Errorf("getISO3166ByNumberPrefix(number=`79161234567`): expected `KZ,RU`, actual `%v`", countries)
+	}
+	if countries := getISO3166ByNumberPrefix("0123"); len(countries) != 0 {
+		t.Errorf("getISO3166ByNumberPrefix(number=`0123`): expected no countries, actual %d", len(countries))
+	}
+}
+
+// Detect country of international numbers among the allowed countries
+var detectCountryAmongTests = []struct {
+	input    string
+	allowed  []string
+	expected string
+	unique   bool
+}{
+	{"+358401234567", nil, "FI", true},
+	{"+358401234567", []string{"AX"}, "", false},
+	{"+358401234567", []string{"FI", "LV"}, "FI", true},
+	{"+358401234567", []string{"fi"}, "FI", true},
+	{"+12025550143", []string{"US", "CA", "GB"}, "US", true},
+	{"+12025550143", []string{"CA", "GB"}, "", false},
+	{"+447700900000", []string{"LV"}, "", false},
+	{"+447700900000", []string{}, "GB", true},
+}
+
+func TestDetectCountryAmong(t *testing.T) {
+	for _, tt := range detectCountryAmongTests {
+		country, unique := DetectCountryAmong(tt.input, tt.allowed)
+		if country.Alpha2 != tt.expected || unique != tt.unique {
+			t.Errorf("DetectCountryAmong(number=`%s`, allowed=`%v`): expected (`%s`, %t), actual (`%s`, %t)", tt.input, tt.allowed, tt.expected, tt.unique, country.Alpha2, unique)
+		}
+	}
+}
+
+// Numbers of the global services with a country code of their own
+var nonGeographicTests = []struct {
+	input    string
+	expected string
+}{
+	{"+800 1234 5678", "International Freephone"},
+	{"+808 1234 5678", "International Shared Cost Service"},
+	{"+870 773 123 456", "Inmarsat"},
+	{"+881 6 1234 5678", "Global Mobile Satellite System"},
+	{"+882 16 1234 5678", "International Networks"},
+	{"+979 123 456 789", "International Premium Rate Service"},
+	{"+800 1234 567", ""},
+	{"+371 25 641 580", ""},
+	{"", ""},
+}
+
+func TestNonGeographic(t *testing.T) {
+	for _, tt := range nonGeographicTests {
+		if nonGeographic := IsNonGeographic(tt.input); nonGeographic != (tt.expected != "") {
+			t.Errorf("IsNonGeographic(number=`%s`): expected `%t`, actual `%t`", tt.input, tt.expected != "", nonGeographic)
+		}
+
+		country, _ := DetectCountry(tt.input)
+		if tt.expected != "" && (country.CountryName != tt.expected || !country.NonGeographic || country.Alpha2 != "001") {
+			t.Errorf("DetectCountry(number=`%s`): expected non-geographic `%s`, actual `%s`", tt.input, tt.expected, country.CountryName)
+		}
+	}
+
+	if number := ParseWithHint("+870 773 123 456", "US"); number != "870773123456" {
+		t.Errorf("ParseWithHint(number=`+870 773 123 456`, hint=`US`): expected `870773123456`, actual `%s`", number)
+	}
+	if number := FormatForRegion("+80012345678", "US"); number != "+800 12345678" {
+		t.Errorf("FormatForRegion(number=`+80012345678`, viewingCountry=`US`): expected `+800 12345678`, actual `%s`", number)
+	}
+	for _, i := range GetISO3166() {
+		if i.NonGeographic {
+			t.Errorf("GetISO3166(): unexpected non-geographic `%s`", i.CountryName)
+		}
+	}
+}
+
+// Split international numbers into country code and national number
+var splitCountryCodeTests = []struct {
+	input string
+	code  string
+	rest  string
+	ok    bool
+}{
+	{"+12425551234", "1", "2425551234", true},
+	{"+1-242-555-1234", "1", "2425551234", true},
+	{"+37125641580", "371", "25641580", true},
+	{"+447700900000", "44", "7700900000", true},
+	{"+358401234567", "358", "401234567", true},
+	{"+80012345678", "800", "12345678", true},
+	{"+371", "", "", false},
+	{"+0123", "", "", false},
+	{"", "", "", false},
+}
+
+func TestSplitCountryCode(t *testing.T) {
+	for _, tt := range splitCountryCodeTests {
+		code, rest, ok := SplitCountryCode(tt.input)
+		if code != tt.code || rest != tt.rest || ok != tt.ok {
+			t.Errorf("SplitCountryCode(number=`%s`): expected (`%s`, `%s`, %t), actual (`%s`, `%s`, %t)", tt.input, tt.code, tt.rest, tt.ok, code, rest, ok)
+		}
+	}
+
+	for country, expected := range map[string]int{"US": 1, "GB": 2, "LV": 3, "lva": 3, "XX": 0} {
+		if length := CountryCodeLength(country); length != expected {
+			t.Errorf("CountryCodeLength(country=`%s`): expected `%d`, actual `%d`", country, expected, length)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package phonenumber
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Diagnose parses the mobile number by country like Parse and returns the human-readable
+// reasons why the number is invalid, e.g. "length 9 not in [10,11]".
+// An empty slice is returned for valid mobile numbers.
+func Diagnose(number string, country string) []string {
+	parsed, iso3166 := parseInternal(number, country)
+	return diagnoseISO3166(number, country, parsed, iso3166)
+}
+
+// diagnoseISO3166 is Diagnose for the number already parsed by the country
+func diagnoseISO3166(number string, country string, parsed string, iso3166 ISO3166) []string {
+	national, reasons := diagnoseLength(number, country, parsed, iso3166, mobileLengths(iso3166))
+	if national == "" {
+		return reasons
+	}
+
+	prefixLength := 0
+	for _, w := range iso3166.MobileBeginWith {
+		prefixLength = max(prefixLength, len(w))
+	}
+	if !hasMobilePrefix(national, iso3166) {
+		prefix := national[:min(max(prefixLength, 1), len(national))]
+		reasons = append(reasons, fmt.Sprintf("prefix %s not in mobile prefixes", prefix))
+	}
+	return reasons
+}
+
+// diagnosePhoneISO3166 is diagnoseISO3166 for the numbers which may be landlines as well,
+// so the lengths of both are accepted and the mobile prefixes do not matter
+func diagnosePhoneISO3166(number string, country string, parsed string, iso3166 ISO3166) []string {
+	lengths := slices.Concat(mobileLengths(iso3166), fixedLineLengths(iso3166))
+	slices.Sort(lengths)
+	national, reasons := diagnoseLength(number, country, parsed, iso3166, slices.Compact(lengths))
+	if national != "" && len(reasons) == 0 {
+		reasons = append(reasons, "not a mobile or fixed-line number")
+	}
+	return reasons
+}
+
+// diagnoseLength returns the national number and the reasons why it is invalid so far,
+// the national number is empty when there is nothing more to diagnose
+func diagnoseLength(number string, country string, parsed string, iso3166 ISO3166, lengths []int) (string, []string) {
+	reasons := []string{}
+	if iso3166.IsZero() {
+		return "", append(reasons, fmt.Sprintf("unknown country '%s'", country))
+	}
+	if Normalize(number) == "" {
+		return "", append(reasons, "no digits in the number")
+	}
+
+	// the country code is only removed when it is there, not e.g. the 1 of 123456 in the United States
+	national := parsed
+	withCountryCode := strings.HasPrefix(parsed, iso3166.CountryCode) && (isInternational(number) ||
+		strings.HasPrefix(Normalize(number), "00"+iso3166.CountryCode) ||
+		indexOfInt(len(parsed)-len(iso3166.CountryCode), iso3166.PhoneNumberLengths) != -1)
+	if withCountryCode {
+		national = nationalNumber(parsed, iso3166)
+	}
+	if !withCountryCode || indexOfInt(len(national), lengths) == -1 {
+		reasons = append(reasons, fmt.Sprintf("length %d not in %s", len(national), formatLengths(lengths)))
+	}
+	return national, reasons
+}
+
+// formatLengths renders the lengths as [10,11]
+func formatLengths(lengths []int) string {
+	s := make([]string, len(lengths))
+	for k, l := range lengths {
+		s[k] = strconv.Itoa(l)
+	}
+	return "[" + strings.Join(s, ",") + "]"
+}
@@ -2,19 +2,19 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/apifonica/phonenumber"
 )
 
 func main() {
-	countries := phonenumber.GetISO3166ByMobileNumber("07933846223")
-	fmt.Println(countries[0].CountryName)
-
-	countries = phonenumber.GetISO3166ByMobileNumber("09061353368")
-	fmt.Println(countries[0].CountryName)
-
-	countries = phonenumber.GetISO3166ByMobileNumber("14855512329")
-	fmt.Println(countries[0].CountryName)
+	for _, number := range []string{"07933846223", "09061353368", "14855512329"} {
+		names := []string{}
+		for _, country := range phonenumber.GetISO3166ByMobileNumber(number) {
+			names = append(names, country.CountryName)
+		}
+		fmt.Println(strings.Join(names, ", "))
+	}
 
 	parsed := phonenumber.ParseWithLandLine("+1 289 2999", "US")
 	fmt.Println(parsed)
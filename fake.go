@@ -0,0 +1,35 @@
+package phonenumber
+
+// minFakeDigits is the shortest national number checked by IsLikelyFake, the shorter
+// numbers are too few digits to tell a pattern from a coincidence
+const minFakeDigits = 6
+
+// IsLikelyFake reports whether the national number of the number is an obvious placeholder:
+// all the same digit, e.g. 555-555-5555, or a run of consecutive digits, e.g. 1234567890.
+// The number doesn't need to be valid, the country code of the international numbers
+// and the national prefix are not a part of the checked digits.
+func IsLikelyFake(number string, country string) bool {
+	digits := Normalize(number)
+	if parsed, iso3166 := parseInternal(number, country); validateLandlineISO3166(parsed, iso3166) {
+		digits = nationalNumber(parsed, iso3166)
+	} else if _, rest, ok := SplitCountryCode(number); ok && isInternational(number) {
+		digits = rest
+	}
+	return isFakeDigits(digits)
+}
+
+// isFakeDigits reports whether the digits are all the same, ascending or descending
+func isFakeDigits(digits string) bool {
+	if len(digits) < minFakeDigits {
+		return false
+	}
+
+	same, ascending, descending := true, true, true
+	for k := 1; k < len(digits); k++ {
+		step := (digits[k] - digits[k-1] + 10) % 10
+		same = same && step == 0
+		ascending = ascending && step == 1
+		descending = descending && step == 9
+	}
+	return same || ascending || descending
+}
@@ -0,0 +1,128 @@
+package phonenumber
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxMatchDigits is the most digits a number found in the text may have:
+// 15 digits of E.164 with the international 00 prefix
+const maxMatchDigits = 17
+
+// dateRegexp matches the dates, e.g. 2024-01-15 or 15.01.2024, which may pass as numbers
+var dateRegexp = regexp.MustCompile(`^(\d{4}[-/.]\d{1,2}[-/.]\d{1,2}|\d{1,2}[-/.]\d{1,2}[-/.]\d{4})$`)
+
+// NumberMatch is a number found in the text by FindNumbers
+type NumberMatch struct {
+	// Text is the number as written, text[Start:End]
+	Text  string
+	Start int
+	End   int
+	E164  string
+}
+
+// FindNumbers returns the valid mobile and landline numbers written in the text, e.g. an email.
+// Numbers with '+' are parsed by their country code, the others by the default country.
+// A run of digits and separators holding several numbers is split, the longest valid
+// number is taken first. Runs glued to letters or following '#', e.g. order IDs, and dates are skipped.
+func FindNumbers(text string, defaultCountry string) []NumberMatch {
+	result := []NumberMatch{}
+	for start := 0; start < len(text); {
+		r, size := utf8.DecodeRuneInString(text[start:])
+		if !isNumberStart(r) {
+			start += size
+			continue
+		}
+
+		end := start
+		for end < len(text) {
+			r, size := utf8.DecodeRuneInString(text[end:])
+			if !isNumberStart(r) && !isNumberSeparator(r) {
+				break
+			}
+			end += size
+		}
+		next := end
+		for end > start {
+			r, size := utf8.DecodeLastRuneInString(text[start:end])
+			if !isNumberSeparator(r) {
+				break
+			}
+			end -= size
+		}
+		if !isGluedToLetter(text, start, end) {
+			result = append(result, findInRun(text, start, end, defaultCountry)...)
+		}
+		start = next
+	}
+	return result
+}
+
+// findInRun returns the valid numbers within text[start:end], a run of digits and separators
+func findInRun(text string, start int, end int, defaultCountry string) []NumberMatch {
+	// the groups of digits, a number starts and ends at their boundaries
+	var groups [][2]int
+	for k := start; k < end; {
+		r, size := utf8.DecodeRuneInString(text[k:])
+		if _, digit := asciiDigit(r); !digit {
+			k += size
+			continue
+		}
+		groupStart := k
+		for k < end {
+			r, size := utf8.DecodeRuneInString(text[k:])
+			if _, digit := asciiDigit(r); !digit {
+				break
+			}
+			k += size
+		}
+		groups = append(groups, [2]int{groupStart, k})
+	}
+
+	result := []NumberMatch{}
+	for i := 0; i < len(groups); i++ {
+		// the number may be opened with '+' or '(' before its first digits
+		numberStart := groups[i][0]
+		for numberStart > start && strings.ContainsRune("+( ", rune(text[numberStart-1])) {
+			numberStart--
+		}
+		for numberStart < groups[i][0] && text[numberStart] == ' ' {
+			numberStart++
+		}
+
+		for j := len(groups) - 1; j >= i; j-- {
+			raw := text[numberStart:groups[j][1]]
+			if len(Normalize(raw)) > maxMatchDigits || dateRegexp.MatchString(raw) {
+				continue
+			}
+			parsed, iso3166 := parseInternalWithHint(raw, defaultCountry)
+			if validateLandlineISO3166(parsed, iso3166) {
+				result = append(result, NumberMatch{Text: raw, Start: numberStart, End: groups[j][1], E164: parsed})
+				i = j
+				break
+			}
+		}
+	}
+	return result
+}
+
+func isNumberStart(r rune) bool {
+	_, digit := asciiDigit(r)
+	return digit || r == '+' || r == '('
+}
+
+// isNumberSeparator reports whether the character may be written between the digits of a number.
+// The line breaks are not, so the numbers on separate lines are never joined.
+func isNumberSeparator(r rune) bool {
+	return strings.ContainsRune("-./()", r) || r != '\n' && r != '\r' && unicode.IsSpace(r)
+}
+
+// isGluedToLetter reports whether text[start:end] is preceded or followed by a letter,
+// or preceded by '#', which numbers the orders and tickets, e.g. #12025550143
+func isGluedToLetter(text string, start int, end int) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[end:])
+	return unicode.IsLetter(before) || before == '#' || unicode.IsLetter(after)
+}
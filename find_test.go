@@ -0,0 +1,50 @@
+package phonenumber
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Find numbers in free text
+var findNumbersTests = []struct {
+	text     string
+	country  string
+	expected []NumberMatch
+}{
+	{"Call me at 25 641 580 or +44 7700 900000, thanks", "LV", []NumberMatch{
+		{Text: "25 641 580", Start: 11, End: 21, E164: "37125641580"},
+		{Text: "+44 7700 900000", Start: 25, End: 40, E164: "447700900000"},
+	}},
+	{"Office: (817) 569-8900.\nMobile: (202) 555-0143", "US", []NumberMatch{
+		{Text: "(817) 569-8900", Start: 8, End: 22, E164: "18175698900"},
+		{Text: "(202) 555-0143", Start: 32, End: 46, E164: "12025550143"},
+	}},
+	{"25641580 67881727", "LV", []NumberMatch{
+		{Text: "25641580", Start: 0, End: 8, E164: "37125641580"},
+		{Text: "67881727", Start: 9, End: 17, E164: "37167881727"},
+	}},
+	{"+371 25 641 580 x12", "LV", []NumberMatch{
+		{Text: "+371 25 641 580", Start: 0, End: 15, E164: "37125641580"},
+	}},
+	{"Order AB25641580 was shipped on 2024-01-15", "LV", []NumberMatch{}},
+	{"Your order #12025550143 is ready", "US", []NumberMatch{}},
+	{"order #12345678901", "US", []NumberMatch{}},
+	{"Paid 15.01.2024 and 2024/01/15", "LV", []NumberMatch{}},
+	{"no numbers here", "LV", []NumberMatch{}},
+	{"", "LV", []NumberMatch{}},
+}
+
+func TestFindNumbers(t *testing.T) {
+	for _, tt := range findNumbersTests {
+		if matches := FindNumbers(tt.text, tt.country); !reflect.DeepEqual(matches, tt.expected) {
+			t.Errorf("FindNumbers(text=`%s`, country=`%s`): expected `%+v`, actual `%+v`", tt.text, tt.country, tt.expected, matches)
+		}
+	}
+
+	text := "Tel: +371 25 641 580"
+	for _, m := range FindNumbers(text, "") {
+		if text[m.Start:m.End] != m.Text {
+			t.Errorf("FindNumbers(text=`%s`): expected offsets of `%s`, actual `%s`", text, m.Text, text[m.Start:m.End])
+		}
+	}
+}
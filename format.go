@@ -0,0 +1,177 @@
+package phonenumber
+
+import "strings"
+
+// FormatStyle defines how a number is rendered by Format
+type FormatStyle int
+
+const (
+	// FormatE164 renders the number as +12025550143
+	FormatE164 FormatStyle = iota
+	// FormatInternational renders the number as +1 202-555-0143
+	FormatInternational
+	// FormatNational renders the number as (202) 555-0143
+	FormatNational
+	// FormatRFC3966 renders the number as tel:+1-202-555-0143
+	FormatRFC3966
+)
+
+// numberFormat describes how to group the digits of a national number.
+// Each '#' in a pattern is replaced with the next digit of the number,
+// any other character is copied as is.
+type numberFormat struct {
+	// leadingDigits the national number must start with, empty matches any number
+	leadingDigits string
+	national      string
+	international string
+}
+
+// numberFormats contains the grouping rules by country code
+var numberFormats = map[string][]numberFormat{
+	// NANP
+	"1": {
+		{"", "(###) ###-####", "###-###-####"},
+	},
+	// Russia and Kazakhstan, the national format keeps the domestic 8 prefix
+	"7": {
+		{"", "8 (###) ###-##-##", "### ###-##-##"},
+	},
+	// France
+	"33": {
+		{"", "0# ## ## ## ##", "# ## ## ## ##"},
+	},
+	// Spain
+	"34": {
+		{"", "### ## ## ##", "### ## ## ##"},
+	},
+	// United Kingdom
+	"44": {
+		{"2", "0## #### ####", "## #### ####"},
+		{"7", "0#### ######", "#### ######"},
+		{"1", "0#### ######", "#### ######"},
+	},
+	// Australia
+	"61": {
+		{"4", "0### ### ###", "### ### ###"},
+	},
+	// Japan
+	"81": {
+		{"", "0##-####-####", "##-####-####"},
+	},
+	// China
+	"86": {
+		{"1", "### #### ####", "### #### ####"},
+	},
+	// India
+	"91": {
+		{"", "0##### #####", "##### #####"},
+	},
+	// Latvia
+	"371": {
+		{"", "## ### ###", "## ### ###"},
+	},
+}
+
+// Format parses the number by country and renders it in the given style.
+// Mobile and landline numbers are accepted, an empty string is returned for invalid numbers.
+func Format(number string, country string, style FormatStyle) string {
+	parsed, iso3166 := parseInternal(number, country)
+	if !validateLandlineISO3166(parsed, iso3166) {
+		return ""
+	}
+	return formatISO3166(parsed, iso3166, style)
+}
+
+// FormatForRegion renders the international number, e.g. +442079460000, as seen from
+// the viewing country: in the national format when the viewer shares the country code
+// of the number, and in the international format otherwise.
+// An empty string is returned for invalid numbers.
+func FormatForRegion(e164 string, viewingCountry string) string {
+	iso3166, _ := DetectCountry(e164)
+	if iso3166.IsZero() {
+		return ""
+	}
+
+	number := Normalize(e164)
+	if getISO3166ByCountry(viewingCountry).CountryCode == iso3166.CountryCode {
+		return formatISO3166(number, iso3166, FormatNational)
+	}
+	return formatISO3166(number, iso3166, FormatInternational)
+}
+
+func formatISO3166(number string, iso3166 ISO3166, style FormatStyle) string {
+	national := nationalNumber(number, iso3166)
+	format, found := getNumberFormat(national, iso3166)
+
+	switch style {
+	case FormatNational:
+		// without a pattern the national number is dialed with the national prefix
+		if !found {
+			if keepsLeadingZero(iso3166) {
+				return national
+			}
+			return getNationalPrefix(iso3166) + national
+		}
+		return applyPattern(format.national, national)
+	case FormatInternational:
+		if !found {
+			return "+" + iso3166.CountryCode + " " + national
+		}
+		return "+" + iso3166.CountryCode + " " + applyPattern(format.international, national)
+	case FormatRFC3966:
+		international := formatISO3166(number, iso3166, FormatInternational)
+		return "tel:" + strings.Replace(international, " ", "-", -1)
+	default:
+		return "+" + number
+	}
+}
+
+func getNumberFormat(national string, iso3166 ISO3166) (numberFormat, bool) {
+	for _, f := range numberFormats[iso3166.CountryCode] {
+		if strings.HasPrefix(national, f.leadingDigits) && strings.Count(f.national, "#") == len(national) {
+			return f, true
+		}
+	}
+	return numberFormat{}, false
+}
+
+// applyPattern fills the pattern placeholders with the digits.
+// The rest of the pattern is cut off once all the digits are placed.
+func applyPattern(pattern string, digits string) string {
+	var b strings.Builder
+	k := 0
+	for _, c := range pattern {
+		if k == len(digits) && (k > 0 || c == '#') {
+			break
+		}
+		if c != '#' {
+			b.WriteRune(c)
+			continue
+		}
+		b.WriteByte(digits[k])
+		k++
+	}
+	return b.String()
+}
+
+// maskedInvalid is returned by Mask for invalid numbers, so the raw input is never leaked
+const maskedInvalid = "**********"
+
+// Mask parses the number by country and masks all but the last two digits,
+// e.g. +1*********43. The country code is kept as is.
+// Mobile and landline numbers are accepted, invalid numbers are fully masked.
+func Mask(number string, country string) string {
+	return MaskN(number, country, 2)
+}
+
+// MaskN is Mask revealing the last keepLast digits of the national number
+func MaskN(number string, country string, keepLast int) string {
+	parsed, iso3166 := parseInternal(number, country)
+	if !validateLandlineISO3166(parsed, iso3166) {
+		return maskedInvalid
+	}
+
+	national := nationalNumber(parsed, iso3166)
+	keepLast = max(0, min(keepLast, len(national)))
+	return "+" + iso3166.CountryCode + strings.Repeat("*", len(national)-keepLast) + national[len(national)-keepLast:]
+}
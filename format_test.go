@@ -0,0 +1,155 @@
+package phonenumber
+
+import (
+	"strings"
+	"testing"
+)
+
+// Format numbers in different styles
+var formatTests = []struct {
+	input    string
+	country  string
+	style    FormatStyle
+	expected string
+}{
+	{"+1 202-555-0143", "US", FormatE164, "+12025550143"},
+	{"+1 202-555-0143", "US", FormatInternational, "+1 202-555-0143"},
+	{"+1 202-555-0143", "US", FormatNational, "(202) 555-0143"},
+	{"+1 202-555-0143", "US", FormatRFC3966, "tel:+1-202-555-0143"},
+	{"020 7946 0000", "GB", FormatNational, "020 7946 0000"},
+	{"020 7946 0000", "GB", FormatInternational, "+44 20 7946 0000"},
+	{"07400 123456", "GB", FormatInternational, "+44 7400 123456"},
+	{"090 6135 3368", "JP", FormatNational, "090-6135-3368"},
+	{"090 6135 3368", "JP", FormatRFC3966, "tel:+81-90-6135-3368"},
+	{"+371 25 641 580", "LV", FormatInternational, "+371 25 641 580"},
+	{"06 12 34 56 78", "FR", FormatNational, "06 12 34 56 78"},
+	{"8 916 123-45-67", "RU", FormatNational, "8 (916) 123-45-67"},
+	{"+7 916 123-45-67", "RU", FormatNational, "8 (916) 123-45-67"},
+	{"+7 916 123-45-67", "RU", FormatInternational, "+7 916 123-45-67"},
+	{"+7 499 709 88 33", "RU", FormatNational, "8 (499) 709-88-33"},
+
+	// Countries without grouping rules
+	{"+3726823000", "EE", FormatInternational, "+372 6823000"},
+	{"+3726823000", "EE", FormatNational, "06823000"},
+	{"+43 1 5334567", "AT", FormatNational, "015334567"},
+	{"+56 2 2123 4567", "CL", FormatNational, "0221234567"},
+
+	// Invalid numbers
+	{"+1 289 2999", "US", FormatInternational, ""},
+	{"38341234999", "XXXK", FormatE164, ""},
+}
+
+func TestFormat(t *testing.T) {
+	for _, tt := range formatTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			formatted := Format(tt.input, tt.country, tt.style)
+			if formatted != tt.expected {
+				t.Errorf("Format(number=`%s`, country=`%s`, style=%d): expected `%s`, actual `%s`", tt.input, tt.country, tt.style, tt.expected, formatted)
+			}
+			// the national format is dialable, so it is parsed back to the same number
+			if tt.style == FormatNational && formatted != "" && ParseWithLandLine(formatted, tt.country) != ParseWithLandLine(tt.input, tt.country) {
+				t.Errorf("ParseWithLandLine(number=`%s`, country=`%s`): expected `%s`, actual `%s`", formatted, tt.country, ParseWithLandLine(tt.input, tt.country), ParseWithLandLine(formatted, tt.country))
+			}
+		})
+	}
+}
+
+// Format numbers as seen from the viewing country
+var formatForRegionTests = []struct {
+	input    string
+	viewer   string
+	expected string
+}{
+	{"+442079460000", "GB", "020 7946 0000"},
+	{"+442079460000", "US", "+44 20 7946 0000"},
+	{"+12025550143", "US", "(202) 555-0143"},
+	{"+12045550143", "US", "(204) 555-0143"},
+	{"+12025550143", "GB", "+1 202-555-0143"},
+	{"+37125641580", "", "+371 25 641 580"},
+	{"+99912", "GB", ""},
+}
+
+func TestFormatForRegion(t *testing.T) {
+	for _, tt := range formatForRegionTests {
+		if formatted := FormatForRegion(tt.input, tt.viewer); formatted != tt.expected {
+			t.Errorf("FormatForRegion(number=`%s`, viewer=`%s`): expected `%s`, actual `%s`", tt.input, tt.viewer, tt.expected, formatted)
+		}
+	}
+}
+
+// Mask numbers for logs
+var maskTests = []struct {
+	input    string
+	country  string
+	keepLast int
+	expected string
+}{
+	{"+1 202-555-0143", "US", 2, "+1********43"},
+	{"+371 25 641 580", "LV", 2, "+371******80"},
+	{"+371 (67) 881-727", "LV", 4, "+371****1727"},
+	{"+371 25 641 580", "LV", 0, "+371********"},
+	{"+371 25 641 580", "LV", 20, "+37125641580"},
+	{"+371 25 641 580", "LV", -1, "+371********"},
+
+	// Invalid numbers
+	{"+1 289 2999", "US", 2, maskedInvalid},
+	{"38341234999", "XXXK", 2, maskedInvalid},
+}
+
+func TestMask(t *testing.T) {
+	for _, tt := range maskTests {
+		if masked := MaskN(tt.input, tt.country, tt.keepLast); masked != tt.expected {
+			t.Errorf("MaskN(number=`%s`, country=`%s`, keepLast=%d): expected `%s`, actual `%s`", tt.input, tt.country, tt.keepLast, tt.expected, masked)
+		}
+		if tt.keepLast == 2 {
+			if masked := Mask(tt.input, tt.country); masked != tt.expected {
+				t.Errorf("Mask(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, masked)
+			}
+		}
+	}
+}
+
+// Format numbers as they are typed
+var asYouTypeTests = []struct {
+	input    string
+	country  string
+	expected []string
+}{
+	{"2025550143", "US", []string{"(2", "(20", "(202", "(202) 5", "(202) 55", "(202) 555", "(202) 555-0", "(202) 555-01", "(202) 555-014", "(202) 555-0143"}},
+	{"+12025550143", "US", []string{"+", "+1", "+1 2", "+1 20", "+1 202", "+1 202-5", "+1 202-55", "+1 202-555", "+1 202-555-0", "+1 202-555-01", "+1 202-555-014", "+1 202-555-0143"}},
+	{"02079460000", "GB", []string{"0", "02", "020", "020 7", "020 79", "020 794", "020 7946", "020 7946 0", "020 7946 00", "020 7946 000", "020 7946 0000"}},
+	{"07400123456", "GB", []string{"0", "07", "074", "0740", "07400", "07400 1", "07400 12", "07400 123", "07400 1234", "07400 12345", "07400 123456"}},
+	{"+371 25-641-580", "LV", []string{"+", "+3", "+37", "+371", "+371 2", "+371 25", "+371 25 6", "+371 25 64", "+371 25 641", "+371 25 641 5", "+371 25 641 58", "+371 25 641 580"}},
+	{"89161234567", "RU", []string{"8", "8 (9", "8 (91", "8 (916", "8 (916) 1", "8 (916) 12", "8 (916) 123", "8 (916) 123-4", "8 (916) 123-45", "8 (916) 123-45-6", "8 (916) 123-45-67"}},
+
+	// No grouping rules for the country or the number is too long
+	{"6823000", "EE", []string{"6", "68", "682", "682 3", "682 30", "682 300", "682 300 0"}},
+	{"202555014399", "US", []string{"(2", "(20", "(202", "(202) 5", "(202) 55", "(202) 555", "(202) 555-0", "(202) 555-01", "(202) 555-014", "(202) 555-0143", "202 555 014 39", "202 555 014 399"}},
+}
+
+func TestAsYouTypeFormatter(t *testing.T) {
+	for _, tt := range asYouTypeTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			f := NewAsYouTypeFormatter(tt.country)
+			actual := []string{}
+			for _, d := range tt.input {
+				if d == ' ' || d == '-' {
+					continue
+				}
+				actual = append(actual, f.InputDigit(d))
+			}
+			if strings.Join(actual, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("AsYouTypeFormatter(number=`%s`, country=`%s`): expected `%v`, actual `%v`", tt.input, tt.country, tt.expected, actual)
+			}
+
+			f.Clear()
+			if formatted := f.InputDigit('5'); formatted != "5" && formatted != "(5" {
+				t.Errorf("AsYouTypeFormatter(country=`%s`) after Clear(): unexpected `%s`", tt.country, formatted)
+			}
+		})
+	}
+}
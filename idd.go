@@ -0,0 +1,147 @@
+package phonenumber
+
+import "strings"
+
+// defaultExitCodes is the international exit code used by most of the countries
+var defaultExitCodes = []string{"00"}
+
+// exitCodes contains the international exit codes of the countries not using 00 only.
+// The NANP countries are not listed, all of them use 011.
+var exitCodes = map[string][]string{
+	"AU": {"0011"},
+	"BR": {"0012", "0014", "0015", "0021", "0031", "0041", "0043"},
+	"CU": {"119"},
+	"HK": {"001", "002", "0082"},
+	"ID": {"001", "007", "008", "009"},
+	"IL": {"00", "012", "013", "014"},
+	"JP": {"010"},
+	"KE": {"000"},
+	"KH": {"001"},
+	"KR": {"001", "002", "005", "006"},
+	"KZ": {"810", "00"},
+	"MN": {"001"},
+	"NG": {"009"},
+	"RU": {"810", "00"},
+	"SG": {"001", "008"},
+	"TH": {"001"},
+	"TW": {"002"},
+	"TZ": {"000"},
+	"UG": {"000"},
+}
+
+// populateExitCodes sets the international exit codes of the countries
+func populateExitCodes() {
+	for k, i := range iso3166Datas {
+		if codes, exists := exitCodes[i.Alpha2]; exists {
+			iso3166Datas[k].ExitCodes = codes
+		} else if i.CountryCode == "1" {
+			iso3166Datas[k].ExitCodes = []string{"011"}
+		} else {
+			iso3166Datas[k].ExitCodes = defaultExitCodes
+		}
+	}
+}
+
+// nationalPrefixes contains the national prefixes of the countries not using 0.
+// The NANP countries are not listed, all of them use 1.
+var nationalPrefixes = map[string]string{
+	"HU": "06",
+	"KZ": "8",
+	"RU": "8",
+}
+
+// leadingZeroCountries contains the countries without a national prefix,
+// where the leading zero is a part of the national number
+var leadingZeroCountries = []string{"CG", "CI", "GA", "IT"}
+
+// trunkPrefixInMobileCountries contains the countries where the mobile numbers are often
+// written with the national prefix after the country code, e.g. +49 (0)151 12345678
+var trunkPrefixInMobileCountries = []string{"AT", "BE", "CH", "DE", "FR", "NL"}
+
+// populateNationalPrefixes sets the national prefixes of the countries
+func populateNationalPrefixes() {
+	for k, i := range iso3166Datas {
+		iso3166Datas[k].TrunkPrefixInMobile = indexOfString(i.Alpha2, trunkPrefixInMobileCountries) != -1
+		if indexOfString(i.Alpha2, leadingZeroCountries) != -1 {
+			iso3166Datas[k].KeepsLeadingZero = true
+		} else if prefix, exists := nationalPrefixes[i.Alpha2]; exists {
+			iso3166Datas[k].NationalPrefix = prefix
+		} else if i.CountryCode == "1" {
+			iso3166Datas[k].NationalPrefix = "1"
+		} else {
+			iso3166Datas[k].NationalPrefix = "0"
+		}
+	}
+}
+
+// ParseWithIDD is Parse mobile number dialed from the given country, where the number
+// may start with the international exit code of that country, e.g. 011 44 7400 123456
+// dialed from US. The country is then detected from the rest of the number.
+// Numbers without exit code are parsed by the dialing country.
+func ParseWithIDD(number string, dialingFromCountry string) string {
+	parsed, iso3166 := parseInternalWithIDD(number, dialingFromCountry)
+	valid := validateMobileISO3166(parsed, iso3166)
+	observeParse(iso3166, valid, valid)
+	if valid {
+		return parsed
+	}
+	return ""
+}
+
+func parseInternalWithIDD(number string, dialingFromCountry string) (string, ISO3166) {
+	if isInternational(number) {
+		return parseInternational(number)
+	}
+
+	iso3166 := getISO3166ByCountry(dialingFromCountry)
+	digits, _ := splitExtension(number)
+	digits = Normalize(digits)
+	if exitCode := getExitCode(digits, iso3166); exitCode != "" {
+		return parseInternational(strings.TrimPrefix(digits, exitCode))
+	}
+	return parseInternal(number, dialingFromCountry)
+}
+
+// getExitCode returns the longest exit code of the country the number starts with
+func getExitCode(number string, iso3166 ISO3166) string {
+	codes := iso3166.ExitCodes
+	if len(codes) == 0 && !iso3166.IsZero() {
+		codes = defaultExitCodes
+	}
+
+	exitCode := ""
+	for _, c := range codes {
+		if len(c) > len(exitCode) && strings.HasPrefix(number, c) {
+			exitCode = c
+		}
+	}
+	return exitCode
+}
+
+// DialableFrom returns the digits to dial the international number, e.g. +447700900000,
+// from the given country: the national prefix and the national number within the country
+// code, the first exit code of the dialing country and the number otherwise.
+// Mobile and landline numbers are accepted, an empty string is returned for invalid numbers
+// and unknown dialing countries.
+func DialableFrom(e164 string, fromCountry string) string {
+	iso3166, _ := DetectCountry(e164)
+	number := Normalize(e164)
+	from := getISO3166ByCountry(fromCountry)
+	if from.IsZero() || !validateLandlineISO3166(number, iso3166) {
+		return ""
+	}
+
+	if from.CountryCode != iso3166.CountryCode {
+		exitCodes := from.ExitCodes
+		if len(exitCodes) == 0 {
+			exitCodes = defaultExitCodes
+		}
+		return exitCodes[0] + number
+	}
+
+	national := nationalNumber(number, iso3166)
+	if keepsLeadingZero(iso3166) {
+		return national
+	}
+	return getNationalPrefix(iso3166) + national
+}
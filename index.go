@@ -0,0 +1,57 @@
+package phonenumber
+
+import "strings"
+
+// countryIndex maps the alpha2, alpha3, names and aliases of the countries, uppercased
+// and without whitespace, to their indexes in the table. The first country wins when
+// several share a key, like the linear scan of the table would.
+type countryIndex struct {
+	alpha2  map[string]int
+	alpha3  map[string]int
+	name    map[string]int
+	aliases map[string]int
+}
+
+func newCountryIndex(datas []ISO3166) *countryIndex {
+	index := &countryIndex{
+		alpha2:  make(map[string]int, len(datas)),
+		alpha3:  make(map[string]int, len(datas)),
+		name:    make(map[string]int, len(datas)),
+		aliases: make(map[string]int, len(countryAliases)),
+	}
+	for k, i := range datas {
+		addCountryKey(index.alpha2, i.Alpha2, k)
+		addCountryKey(index.alpha3, i.Alpha3, k)
+		addCountryKey(index.name, i.CountryName, k)
+	}
+	for alias, alpha2 := range countryAliases {
+		if k, exists := index.alpha2[alpha2]; exists {
+			addCountryKey(index.aliases, alias, k)
+		}
+	}
+	return index
+}
+
+func addCountryKey(keys map[string]int, key string, k int) {
+	key = strings.ToUpper(stripSpaces(key))
+	if _, exists := keys[key]; !exists && key != "" {
+		keys[key] = k
+	}
+}
+
+// find resolves the country by alpha2, alpha3, name or alias, in this order,
+// among the countries the index was built for
+func (x *countryIndex) find(country string, datas []ISO3166) ISO3166 {
+	country = strings.ToUpper(stripSpaces(country))
+	if country == "" {
+		// There is no default country, the empty sentinel is returned
+		return ISO3166{}
+	}
+
+	for _, keys := range [...]map[string]int{x.alpha2, x.alpha3, x.name, x.aliases} {
+		if k, exists := keys[country]; exists {
+			return datas[k]
+		}
+	}
+	return ISO3166{}
+}
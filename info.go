@@ -0,0 +1,34 @@
+package phonenumber
+
+// NumberInfo holds everything known about a number, as returned by Info
+type NumberInfo struct {
+	E164          string
+	National      string
+	International string
+	Valid         bool
+	Type          NumberType
+	// Country the number was parsed by, set even when the number is invalid
+	Country   ISO3166
+	Extension string
+}
+
+// Info parses the mobile or landline number by country and returns all the facts about it:
+// the E.164, national and international forms, the number type and the extension.
+// Only Country is set for invalid numbers.
+func Info(number string, country string) NumberInfo {
+	number, ext := splitExtension(number)
+	parsed, iso3166 := parseInternal(number, country)
+	info := NumberInfo{Country: iso3166}
+
+	info.Type = getNumberTypeISO3166(parsed, iso3166)
+	if info.Type == Unknown {
+		return info
+	}
+
+	info.E164 = parsed
+	info.National = formatISO3166(parsed, iso3166, FormatNational)
+	info.International = formatISO3166(parsed, iso3166, FormatInternational)
+	info.Valid = true
+	info.Extension = ext
+	return info
+}
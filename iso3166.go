@@ -1,29 +1,298 @@
 package phonenumber
 
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
 // ISO3166 ...
 type ISO3166 struct {
-	Alpha2             string
-	Alpha3             string
-	CountryCode        string
-	CountryName        string
-	MobileBeginWith    []string
-	PhoneNumberLengths []int
-}
-
-func init() {
-	// Run once during package initialization in order to avoid data races
-	// https://go.dev/doc/effective_go#init
-	populateISO3166()
+	Alpha2             string   `json:"alpha2"`
+	Alpha3             string   `json:"alpha3"`
+	CountryCode        string   `json:"country_code"`
+	CountryName        string   `json:"country_name"`
+	MobileBeginWith    []string `json:"mobile_begin_with"`
+	PhoneNumberLengths []int    `json:"phone_number_lengths"`
+
+	// Whether any number of a mobile length is mobile, for the countries not telling the mobile
+	// numbers apart by prefix. The empty MobileBeginWith prefixes never match otherwise.
+	AnyMobilePrefix bool `json:"any_mobile_prefix,omitempty"`
+
+	// National (trunk) prefix dialed before the national number within the country, 0 when empty
+	NationalPrefix string `json:"national_prefix,omitempty"`
+
+	// Whether the leading zero is a significant part of the national number, e.g. 06 for Rome,
+	// so it is never stripped, neither before nor after the country code
+	KeepsLeadingZero bool `json:"keeps_leading_zero,omitempty"`
+
+	// Whether the national prefix is often kept in the mobile numbers after the country code,
+	// e.g. 0049 (0)151 12345678, so it is removed when the number is only valid without it
+	TrunkPrefixInMobile bool `json:"trunk_prefix_in_mobile,omitempty"`
+
+	// Whether the legacy 8-digit mobile numbers get the mandatory 9 after the 2-digit area code,
+	// e.g. 11 8765-4321 in Brazil, so both the old and the new forms are parsed
+	MobileNinthDigit bool `json:"mobile_ninth_digit,omitempty"`
+
+	// Tokens of the mobile numbers dialed differently within the country and from abroad,
+	// e.g. 15 after the area code domestically and 9 before it internationally in Argentina.
+	// The domestic form is normalized to the international one.
+	MobileDomesticInfix       string `json:"mobile_domestic_infix,omitempty"`
+	MobileInternationalPrefix string `json:"mobile_international_prefix,omitempty"`
+
+	// Lengths of the national numbers by number type, both are optional and
+	// PhoneNumberLengths is used instead when empty
+	MobileLengths    []int `json:"mobile_lengths,omitempty"`
+	FixedLineLengths []int `json:"fixed_line_lengths,omitempty"`
+
+	// Prefixes of the national numbers for the non-geographic number types
+	TollFreeBeginWith    []string `json:"toll_free_begin_with,omitempty"`
+	PremiumRateBeginWith []string `json:"premium_rate_begin_with,omitempty"`
+	SharedCostBeginWith  []string `json:"shared_cost_begin_with,omitempty"`
+	VOIPBeginWith        []string `json:"voip_begin_with,omitempty"`
+
+	// Area codes of the national numbers, used to tell apart countries sharing the country code
+	AreaCodes []string `json:"area_codes,omitempty"`
+
+	// International exit codes dialed before the country code when calling abroad, 00 when empty
+	ExitCodes []string `json:"exit_codes,omitempty"`
+
+	// Mobile carrier names by the national number prefix
+	Carriers map[string]string `json:"carriers,omitempty"`
+
+	// SMS and voice capabilities by the national number prefix, where they differ from the defaults
+	Capabilities map[string]Capability `json:"capabilities,omitempty"`
+
+	// Continent the country is grouped with: Africa, Americas, Asia, Europe or Oceania
+	Region string `json:"region,omitempty"`
+
+	// Whether the entry is a global service, e.g. +800 International Freephone, not a country
+	NonGeographic bool `json:"non_geographic,omitempty"`
 }
 
-var iso3166Datas []ISO3166
+var (
+	// ErrInvalidCountry is returned when registering a country without the required fields
+	ErrInvalidCountry = errors.New("phonenumber: invalid country")
+	// ErrCountryExists is returned when registering a country which is already registered
+	ErrCountryExists = errors.New("phonenumber: country already exists")
+)
+
+var (
+	iso3166Once  sync.Once
+	iso3166Lock  = sync.RWMutex{}
+	iso3166Datas []ISO3166
+	// iso3166Trie and iso3166Index are rebuilt every time the countries are added or replaced
+	iso3166Trie  *countryTrie
+	iso3166Index *countryIndex
+	// iso3166Builtin is the table as loaded, before any country is registered or overridden
+	iso3166Builtin []ISO3166
+	// iso3166Version changes every time the countries or the default country change,
+	// the results cached before are stale
+	iso3166Version atomic.Uint64
+)
 
 // GetISO3166 returns the ISO3166 configuration for each country.
-// Data are loaded during package initialization.
+// Data are loaded exactly once, on first use, and the same slice is shared
+// by all the callers, so it must not be modified. Use GetISO3166Copy
+// to get a copy which is safe to modify.
 func GetISO3166() []ISO3166 {
+	iso3166Once.Do(loadISO3166)
+	iso3166Lock.RLock()
+	defer iso3166Lock.RUnlock()
 	return iso3166Datas
 }
 
+// RegisterCountry adds the country to the ones used by all the parse,
+// validate and detect functions.
+func RegisterCountry(iso3166 ISO3166) error {
+	iso3166Once.Do(loadISO3166)
+	iso3166Lock.Lock()
+	defer iso3166Lock.Unlock()
+	datas, err := registerCountry(iso3166Datas, iso3166)
+	if err != nil {
+		return err
+	}
+	iso3166Datas = datas
+	iso3166Trie = newCountryTrie(iso3166Datas)
+	iso3166Index = newCountryIndex(iso3166Datas)
+	iso3166Version.Add(1)
+	return nil
+}
+
+// OverrideCountry replaces the configuration of the already known country
+func OverrideCountry(alpha2 string, iso3166 ISO3166) error {
+	iso3166Once.Do(loadISO3166)
+	iso3166Lock.Lock()
+	defer iso3166Lock.Unlock()
+	datas, err := overrideCountry(iso3166Datas, alpha2, iso3166)
+	if err != nil {
+		return err
+	}
+	iso3166Datas = datas
+	iso3166Trie = newCountryTrie(iso3166Datas)
+	iso3166Index = newCountryIndex(iso3166Datas)
+	iso3166Version.Add(1)
+	return nil
+}
+
+// registerCountry returns a copy of the countries with the country added
+func registerCountry(datas []ISO3166, iso3166 ISO3166) ([]ISO3166, error) {
+	if iso3166.Alpha2 == "" || iso3166.CountryCode == "" || len(iso3166.PhoneNumberLengths) == 0 {
+		return nil, ErrInvalidCountry
+	}
+	if indexOfAlpha2(iso3166.Alpha2, datas) != -1 {
+		return nil, ErrCountryExists
+	}
+
+	return append(copyCountries(datas, 1), iso3166.clone()), nil
+}
+
+// overrideCountry returns a copy of the countries with the country replaced
+func overrideCountry(datas []ISO3166, alpha2 string, iso3166 ISO3166) ([]ISO3166, error) {
+	if iso3166.Alpha2 == "" || iso3166.CountryCode == "" || len(iso3166.PhoneNumberLengths) == 0 {
+		return nil, ErrInvalidCountry
+	}
+	k := indexOfAlpha2(alpha2, datas)
+	if k == -1 {
+		return nil, ErrUnknownCountry
+	}
+
+	result := copyCountries(datas, 0)
+	result[k] = iso3166.clone()
+	return result, nil
+}
+
+// copyCountries returns a copy of the countries with room for the extra ones.
+// The slice returned by GetISO3166 is shared, so it is never modified in place.
+func copyCountries(datas []ISO3166, extra int) []ISO3166 {
+	result := make([]ISO3166, len(datas), len(datas)+extra)
+	copy(result, datas)
+	return result
+}
+
+// LoadISO3166FromJSON merges the countries from a JSON array of ISO3166 into the registry.
+// The countries are matched by Alpha2, the existing ones are replaced and the new ones are added.
+// Nothing is loaded when any of the countries is invalid.
+func LoadISO3166FromJSON(r io.Reader) error {
+	countries := []ISO3166{}
+	if err := json.NewDecoder(r).Decode(&countries); err != nil {
+		return err
+	}
+	for _, iso3166 := range countries {
+		if iso3166.Alpha2 == "" || iso3166.CountryCode == "" || len(iso3166.PhoneNumberLengths) == 0 {
+			return ErrInvalidCountry
+		}
+	}
+
+	iso3166Once.Do(loadISO3166)
+	iso3166Lock.Lock()
+	datas := copyCountries(iso3166Datas, len(countries))
+	for _, iso3166 := range countries {
+		if k := indexOfAlpha2(iso3166.Alpha2, datas); k != -1 {
+			datas[k] = iso3166.clone()
+		} else {
+			datas = append(datas, iso3166.clone())
+		}
+	}
+	iso3166Datas = datas
+	iso3166Trie = newCountryTrie(iso3166Datas)
+	iso3166Index = newCountryIndex(iso3166Datas)
+	iso3166Version.Add(1)
+	iso3166Lock.Unlock()
+
+	// The regexps of the replaced countries may be stale
+	rCache.reset()
+	return nil
+}
+
+func indexOfAlpha2(alpha2 string, datas []ISO3166) int {
+	alpha2 = strings.ToUpper(alpha2)
+	for k, i := range datas {
+		if i.Alpha2 == alpha2 {
+			return k
+		}
+	}
+	return -1
+}
+
+// IsZero reports whether the country is the empty ISO3166{} returned when no country is found
+func (i ISO3166) IsZero() bool {
+	return i.Alpha2 == "" && i.CountryCode == ""
+}
+
+// String returns the country for logging, e.g. United States (US/+1), or <unknown> for ISO3166{}
+func (i ISO3166) String() string {
+	if i.IsZero() {
+		return "<unknown>"
+	}
+	return i.CountryName + " (" + i.Alpha2 + "/+" + i.CountryCode + ")"
+}
+
+// MobilePrefixes returns the MobileBeginWith of the country, nil for unknown countries
+func MobilePrefixes(country string) []string {
+	iso3166 := getISO3166ByCountry(country)
+	if iso3166.IsZero() {
+		return nil
+	}
+	return append([]string{}, iso3166.MobileBeginWith...)
+}
+
+// PhoneLengths returns the PhoneNumberLengths of the country, nil for unknown countries
+func PhoneLengths(country string) []int {
+	iso3166 := getISO3166ByCountry(country)
+	if iso3166.IsZero() {
+		return nil
+	}
+	return append([]int{}, iso3166.PhoneNumberLengths...)
+}
+
+// GetISO3166Copy returns a deep copy of the ISO3166 configuration for each country
+func GetISO3166Copy() []ISO3166 {
+	datas := GetISO3166()
+	result := make([]ISO3166, len(datas))
+	for k, i := range datas {
+		result[k] = i.clone()
+	}
+	return result
+}
+
+// loadISO3166 populates the countries and their additional metadata
+func loadISO3166() {
+	populateISO3166()
+	populateNumberTypes()
+	populateNumberLengths()
+	populateAreaCodes()
+	populateExitCodes()
+	populateNationalPrefixes()
+	populateCarriers()
+	populateCapabilities()
+	populateRegions()
+	iso3166Trie = newCountryTrie(iso3166Datas)
+	iso3166Index = newCountryIndex(iso3166Datas)
+	iso3166Builtin = iso3166Datas
+}
+
+// clone returns a copy of the country, which does not share slices with the original
+func (i ISO3166) clone() ISO3166 {
+	i.MobileBeginWith = append([]string(nil), i.MobileBeginWith...)
+	i.PhoneNumberLengths = append([]int(nil), i.PhoneNumberLengths...)
+	i.MobileLengths = append([]int(nil), i.MobileLengths...)
+	i.FixedLineLengths = append([]int(nil), i.FixedLineLengths...)
+	i.TollFreeBeginWith = append([]string(nil), i.TollFreeBeginWith...)
+	i.PremiumRateBeginWith = append([]string(nil), i.PremiumRateBeginWith...)
+	i.SharedCostBeginWith = append([]string(nil), i.SharedCostBeginWith...)
+	i.VOIPBeginWith = append([]string(nil), i.VOIPBeginWith...)
+	i.AreaCodes = append([]string(nil), i.AreaCodes...)
+	i.ExitCodes = append([]string(nil), i.ExitCodes...)
+	i.Carriers = maps.Clone(i.Carriers)
+	i.Capabilities = maps.Clone(i.Capabilities)
+	return i
+}
+
 // populateISO3166 contains the definitions of the per-country mobile number configuration.
 // It operates on the iso3166Datas global variable and will return it after population.
 func populateISO3166() {
@@ -135,7 +404,7 @@ func populateISO3166() {
 	i.CountryCode = "54"
 	i.CountryName = "Argentina"
 	i.MobileBeginWith = []string{"6", "7", "8", "9"}
-	i.PhoneNumberLengths = []int{10, 11, 12, 13, 14}
+	i.PhoneNumberLengths = []int{10, 11}
 	iso3166Datas = append(iso3166Datas, i)
 
 	i.Alpha2 = "AM"
@@ -199,7 +468,7 @@ func populateISO3166() {
 	i.CountryCode = "32"
 	i.CountryName = "Belgium"
 	i.MobileBeginWith = []string{"4"}
-	i.PhoneNumberLengths = []int{9}
+	i.PhoneNumberLengths = []int{8, 9}
 	iso3166Datas = append(iso3166Datas, i)
 
 	i.Alpha2 = "BJ"
@@ -487,7 +756,7 @@ func populateISO3166() {
 	i.CountryCode = "49"
 	i.CountryName = "Germany"
 	i.MobileBeginWith = []string{"15", "16", "17"}
-	i.PhoneNumberLengths = []int{10, 11, 12, 13, 14}
+	i.PhoneNumberLengths = []int{6, 7, 8, 9, 10, 11, 12}
 	iso3166Datas = append(iso3166Datas, i)
 
 	i.Alpha2 = "DJ"
@@ -638,8 +907,8 @@ func populateISO3166() {
 	i.Alpha3 = "GBR"
 	i.CountryCode = "44"
 	i.CountryName = "United Kingdom"
-	i.MobileBeginWith = []string{"7", "07"}
-	i.PhoneNumberLengths = []int{10, 11}
+	i.MobileBeginWith = []string{"7"}
+	i.PhoneNumberLengths = []int{10}
 	iso3166Datas = append(iso3166Datas, i)
 
 	i.Alpha2 = "GE"
@@ -799,7 +1068,7 @@ func populateISO3166() {
 	i.CountryCode = "36"
 	i.CountryName = "Hungary"
 	i.MobileBeginWith = []string{"20", "30", "50", "31", "71", "70"}
-	i.PhoneNumberLengths = []int{9, 12}
+	i.PhoneNumberLengths = []int{8, 9, 12}
 	iso3166Datas = append(iso3166Datas, i)
 
 	i.Alpha2 = "ID"
@@ -823,7 +1092,7 @@ func populateISO3166() {
 	i.CountryCode = "353"
 	i.CountryName = "Ireland"
 	i.MobileBeginWith = []string{"82", "83", "84", "85", "86", "87", "88", "89"}
-	i.PhoneNumberLengths = []int{9}
+	i.PhoneNumberLengths = []int{7, 8, 9}
 	iso3166Datas = append(iso3166Datas, i)
 
 	i.Alpha2 = "IR"
@@ -871,7 +1140,7 @@ func populateISO3166() {
 	i.CountryCode = "1"
 	i.CountryName = "Jamaica"
 	i.MobileBeginWith = []string{"876"}
-	i.PhoneNumberLengths = []int{7}
+	i.PhoneNumberLengths = []int{10}
 	iso3166Datas = append(iso3166Datas, i)
 
 	i.Alpha2 = "JO"
@@ -886,8 +1155,8 @@ func populateISO3166() {
 	i.Alpha3 = "JPN"
 	i.CountryCode = "81"
 	i.CountryName = "Japan"
-	i.MobileBeginWith = []string{"70", "80", "90", "070", "080", "090"}
-	i.PhoneNumberLengths = []int{10, 11}
+	i.MobileBeginWith = []string{"70", "80", "90"}
+	i.PhoneNumberLengths = []int{9, 10}
 	iso3166Datas = append(iso3166Datas, i)
 
 	i.Alpha2 = "KZ"
@@ -1094,7 +1363,7 @@ func populateISO3166() {
 	i.Alpha3 = "MEX"
 	i.CountryCode = "52"
 	i.CountryName = "Mexico"
-	i.MobileBeginWith = []string{""}
+	i.MobileBeginWith = []string{}
 	i.PhoneNumberLengths = []int{10, 11}
 	iso3166Datas = append(iso3166Datas, i)
 
@@ -0,0 +1,54 @@
+package phonenumber
+
+// Match is the level of similarity of two numbers
+type Match int
+
+const (
+	// NoMatch means the numbers are different
+	NoMatch Match = iota
+	// ShortNSNMatch means the national significant numbers are equal,
+	// but one of the numbers omits the country code or the country codes can not be compared
+	ShortNSNMatch
+	// ExactMatch means the numbers are parsed to the same E.164 number
+	ExactMatch
+)
+
+// SameNumber reports whether both numbers are valid and are parsed to the same number by country
+func SameNumber(a string, b string, country string) bool {
+	parsedA := ParseWithLandLine(a, country)
+	return parsedA != "" && parsedA == ParseWithLandLine(b, country)
+}
+
+// MatchType compares two numbers, which may be given in different formats
+func MatchType(a string, b string, country string) Match {
+	if SameNumber(a, b, country) {
+		return ExactMatch
+	}
+
+	nationalA, countryCodeA := significantNumber(a, country)
+	nationalB, countryCodeB := significantNumber(b, country)
+	if countryCodeA != "" && countryCodeB != "" && countryCodeA != countryCodeB {
+		return NoMatch
+	}
+	if nationalA != "" && nationalA == nationalB {
+		return ShortNSNMatch
+	}
+	return NoMatch
+}
+
+// significantNumber returns the national significant number and, for international numbers,
+// the country code the number was given with.
+func significantNumber(number string, country string) (national string, countryCode string) {
+	if isInternational(number) {
+		parsed, iso3166 := parseInternational(number)
+		if !iso3166.IsZero() {
+			return nationalNumber(parsed, iso3166), iso3166.CountryCode
+		}
+	}
+
+	parsed, iso3166 := parseInternal(number, country)
+	if validateLandlineISO3166(parsed, iso3166) {
+		return nationalNumber(parsed, iso3166), ""
+	}
+	return leadZeroRegexp.ReplaceAllString(Normalize(number), ""), ""
+}
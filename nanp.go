@@ -0,0 +1,60 @@
+package phonenumber
+
+import "strings"
+
+// nanpAreaCodes contains the area codes of the North American Numbering Plan countries.
+// The mobile prefixes of US and Canada are their area codes, so they are not repeated here.
+var nanpAreaCodes = map[string][]string{
+	"AG": {"268"},
+	"AI": {"264"},
+	"AS": {"684"},
+	"BB": {"246"},
+	"BM": {"441"},
+	"BS": {"242"},
+	"DM": {"767"},
+	"DO": {"809", "829", "849"},
+	"GD": {"473"},
+	"GU": {"671"},
+	"JM": {"658", "876"},
+	"KN": {"869"},
+	"KY": {"345"},
+	"LC": {"758"},
+	"MP": {"670"},
+	"MS": {"664"},
+	"PR": {"787", "939"},
+	"SX": {"721"},
+	"TC": {"649"},
+	"TT": {"868"},
+	"VC": {"784"},
+	"VG": {"284"},
+	"VI": {"340"},
+}
+
+// GetNANPRegion returns the North American Numbering Plan country of the number
+// by its area code. The number may be given with or without the leading 1.
+func GetNANPRegion(number string) ISO3166 {
+	number = Normalize(number)
+	if len(number) == 11 {
+		number = strings.TrimPrefix(number, "1")
+	}
+	if len(number) != 10 {
+		return ISO3166{}
+	}
+
+	for _, i := range GetISO3166() {
+		if i.CountryCode == "1" && indexOfString(number[:3], i.AreaCodes) != -1 {
+			return i
+		}
+	}
+	return ISO3166{}
+}
+
+// matchesNANPAreaCode reports whether the number starts with one of the area codes
+// of the NANP country. The area codes of other countries are not complete,
+// so they and NANP countries without area codes match any number.
+func matchesNANPAreaCode(number string, iso3166 ISO3166) bool {
+	if iso3166.CountryCode != "1" || len(iso3166.AreaCodes) == 0 {
+		return true
+	}
+	return hasAnyPrefix(nationalNumber(number, iso3166), iso3166.AreaCodes)
+}
@@ -0,0 +1,31 @@
+package phonenumber
+
+// nonGeographicDatas contains the global services having a country code of their own,
+// which are not a country. They are not a part of GetISO3166, their Alpha2 and Alpha3
+// are the UN M49 code of the world, 001.
+var nonGeographicDatas = []ISO3166{
+	{Alpha2: "001", Alpha3: "001", CountryCode: "800", CountryName: "International Freephone", PhoneNumberLengths: []int{8}, NonGeographic: true},
+	{Alpha2: "001", Alpha3: "001", CountryCode: "808", CountryName: "International Shared Cost Service", PhoneNumberLengths: []int{8}, NonGeographic: true},
+	{Alpha2: "001", Alpha3: "001", CountryCode: "870", CountryName: "Inmarsat", AnyMobilePrefix: true, PhoneNumberLengths: []int{9}, NonGeographic: true},
+	{Alpha2: "001", Alpha3: "001", CountryCode: "881", CountryName: "Global Mobile Satellite System", AnyMobilePrefix: true, PhoneNumberLengths: []int{9, 10}, NonGeographic: true},
+	{Alpha2: "001", Alpha3: "001", CountryCode: "882", CountryName: "International Networks", PhoneNumberLengths: []int{8, 9, 10, 11, 12}, NonGeographic: true},
+	{Alpha2: "001", Alpha3: "001", CountryCode: "883", CountryName: "International Networks", PhoneNumberLengths: []int{9, 10, 11, 12}, NonGeographic: true},
+	{Alpha2: "001", Alpha3: "001", CountryCode: "979", CountryName: "International Premium Rate Service", PhoneNumberLengths: []int{9}, NonGeographic: true},
+}
+
+// IsNonGeographic reports whether the international number, e.g. +800 1234 5678,
+// is a valid number of a global service rather than of a country
+func IsNonGeographic(number string) bool {
+	return !getNonGeographicByNumber(Normalize(number)).IsZero()
+}
+
+// getNonGeographicByNumber returns the global service the normalized number is valid for,
+// ISO3166{} when there is none
+func getNonGeographicByNumber(digits string) ISO3166 {
+	for _, i := range nonGeographicDatas {
+		if validateLandlineISO3166(digits, i) {
+			return i.clone()
+		}
+	}
+	return ISO3166{}
+}
@@ -0,0 +1,157 @@
+package phonenumber
+
+import "strings"
+
+// NumberType is the type of a phone number
+type NumberType int
+
+const (
+	// Unknown is returned for invalid numbers
+	Unknown NumberType = iota
+	// Mobile numbers have a mobile prefix of the country
+	Mobile
+	// FixedLine numbers are the valid numbers without a prefix of any other type
+	FixedLine
+	// TollFree numbers are free for the caller, e.g. 800 in the United States
+	TollFree
+	// PremiumRate numbers cost the caller more than the regular ones, e.g. 900 in the United States
+	PremiumRate
+	// SharedCost numbers are paid partly by the caller and partly by the receiver, e.g. 0845 in the United Kingdom
+	SharedCost
+	// VOIP numbers are the numbers of the internet telephony, e.g. 056 in the United Kingdom
+	VOIP
+)
+
+// numberTypePrefixes contains the national number prefixes of the non-geographic number types by country
+var numberTypePrefixes = map[string]struct {
+	tollFree    []string
+	premiumRate []string
+	sharedCost  []string
+	voip        []string
+}{
+	"US": {tollFree: []string{"800", "833", "844", "855", "866", "877", "888"}, premiumRate: []string{"900"}},
+	"CA": {tollFree: []string{"800", "833", "844", "855", "866", "877", "888"}, premiumRate: []string{"900"}},
+	"GB": {tollFree: []string{"800", "808"}, premiumRate: []string{"9"}, sharedCost: []string{"843", "844", "845", "870", "871", "872", "873"}, voip: []string{"56"}},
+	"DE": {tollFree: []string{"800"}, premiumRate: []string{"900"}, sharedCost: []string{"180"}, voip: []string{"32"}},
+	"FR": {tollFree: []string{"80"}, premiumRate: []string{"89"}, sharedCost: []string{"81", "82"}, voip: []string{"9"}},
+	"IT": {tollFree: []string{"800", "803"}, premiumRate: []string{"89"}, sharedCost: []string{"84"}},
+	"ES": {tollFree: []string{"800", "900"}, premiumRate: []string{"803", "806", "807", "905"}, sharedCost: []string{"901", "902"}},
+	"RU": {tollFree: []string{"800"}, premiumRate: []string{"809"}},
+	"JP": {tollFree: []string{"800"}, voip: []string{"50"}},
+}
+
+// anyMobilePrefixCountries contains the countries where any number may be mobile,
+// e.g. Mexico since its numbering plan reform in 2019
+var anyMobilePrefixCountries = []string{"MX"}
+
+// mobileNinthDigitCountries contains the countries where the mobile numbers got an extra 9,
+// e.g. Brazil since 2016
+var mobileNinthDigitCountries = []string{"BR"}
+
+// mobileTokens contains the tokens of the mobile numbers by country, where they are
+// dialed after the area code domestically and before it from abroad
+var mobileTokens = map[string]struct {
+	domesticInfix       string
+	internationalPrefix string
+}{
+	"AR": {"15", "9"},
+}
+
+// populateNumberTypes sets the number type prefixes of the countries
+func populateNumberTypes() {
+	for k, i := range iso3166Datas {
+		iso3166Datas[k].AnyMobilePrefix = indexOfString(i.Alpha2, anyMobilePrefixCountries) != -1
+		iso3166Datas[k].MobileNinthDigit = indexOfString(i.Alpha2, mobileNinthDigitCountries) != -1
+		if tokens, exists := mobileTokens[i.Alpha2]; exists {
+			iso3166Datas[k].MobileDomesticInfix = tokens.domesticInfix
+			iso3166Datas[k].MobileInternationalPrefix = tokens.internationalPrefix
+		}
+		if p, exists := numberTypePrefixes[i.Alpha2]; exists {
+			iso3166Datas[k].TollFreeBeginWith = p.tollFree
+			iso3166Datas[k].PremiumRateBeginWith = p.premiumRate
+			iso3166Datas[k].SharedCostBeginWith = p.sharedCost
+			iso3166Datas[k].VOIPBeginWith = p.voip
+		}
+	}
+}
+
+// numberTypeLengths contains the national number lengths by number type for the countries
+// where the mobile and fixed line numbers differ in length
+var numberTypeLengths = map[string]struct {
+	mobile    []int
+	fixedLine []int
+}{
+	"BE": {mobile: []int{9}},
+	"CN": {mobile: []int{11}, fixedLine: []int{10, 11}},
+	"DE": {mobile: []int{10, 11}},
+	"JP": {mobile: []int{10}},
+	"HU": {mobile: []int{9, 12}},
+	"IE": {mobile: []int{9}},
+	"IT": {mobile: []int{9, 10}},
+}
+
+// populateNumberLengths sets the number type lengths of the countries
+func populateNumberLengths() {
+	for k, i := range iso3166Datas {
+		if l, exists := numberTypeLengths[i.Alpha2]; exists {
+			iso3166Datas[k].MobileLengths = l.mobile
+			iso3166Datas[k].FixedLineLengths = l.fixedLine
+		}
+	}
+}
+
+// GetNumberType parses the number by country and returns its type
+func GetNumberType(number string, country string) NumberType {
+	parsed, iso3166 := parseInternal(number, country)
+	return getNumberTypeISO3166(parsed, iso3166)
+}
+
+// ValidateType parses the number by country and reports whether it is a valid number of the type.
+// In the countries not telling the mobile numbers apart from the fixed line ones, e.g. the NANP
+// countries and Mexico, a mobile number of a fixed line length is valid as both types.
+// Unknown is never valid.
+func ValidateType(number string, country string, want NumberType) bool {
+	parsed, iso3166 := parseInternal(number, country)
+	numberType := getNumberTypeISO3166(parsed, iso3166)
+	if numberType == Mobile && want == FixedLine && !tellsMobileApart(iso3166) {
+		return indexOfInt(len(nationalNumber(parsed, iso3166)), fixedLineLengths(iso3166)) != -1
+	}
+	return want != Unknown && numberType == want
+}
+
+// tellsMobileApart reports whether the mobile numbers of the country have prefixes of their own,
+// the NANP mobile prefixes are the area codes shared with the fixed line numbers
+func tellsMobileApart(iso3166 ISO3166) bool {
+	return !iso3166.AnyMobilePrefix && iso3166.CountryCode != "1"
+}
+
+func getNumberTypeISO3166(number string, iso3166 ISO3166) NumberType {
+	valid, mobile := validatePhoneISO3166(number, iso3166)
+	if !valid {
+		return Unknown
+	}
+
+	national := nationalNumber(number, iso3166)
+	switch {
+	case hasAnyPrefix(national, iso3166.TollFreeBeginWith):
+		return TollFree
+	case hasAnyPrefix(national, iso3166.PremiumRateBeginWith):
+		return PremiumRate
+	case hasAnyPrefix(national, iso3166.SharedCostBeginWith):
+		return SharedCost
+	case hasAnyPrefix(national, iso3166.VOIPBeginWith):
+		return VOIP
+	case mobile:
+		return Mobile
+	}
+	return FixedLine
+}
+
+func hasAnyPrefix(number string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(number, p) {
+			return true
+		}
+	}
+	return false
+}
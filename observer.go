@@ -0,0 +1,40 @@
+package phonenumber
+
+import "sync/atomic"
+
+// Observer is notified about the outcome of every number parsed by the Parse functions,
+// including the batch ones, e.g. to count the invalid numbers by country.
+// The validation helpers, e.g. IsValidMobile, are not observed, neither is ParseCandidates,
+// which tries the number in every country and has no single outcome to report.
+type Observer interface {
+	// OnParse is called with the alpha2 of the resolved country, empty for unknown countries
+	OnParse(country string, valid bool, mobile bool)
+}
+
+// observer is loaded on every parse, so it is kept lock free
+var observer atomic.Pointer[Observer]
+
+// SetObserver sets the observer notified by the Parse functions, nil removes it.
+// The observer may be called concurrently and must be safe for that.
+func SetObserver(o Observer) {
+	if o == nil {
+		observer.Store(nil)
+		return
+	}
+	observer.Store(&o)
+}
+
+// observeParse notifies the observer, if any, about the parsed number
+func observeParse(iso3166 ISO3166, valid bool, mobile bool) {
+	if o := observer.Load(); o != nil {
+		(*o).OnParse(iso3166.Alpha2, valid, mobile)
+	}
+}
+
+// observeLandline is observeParse for the numbers validated as landline,
+// where the number type is only checked when there is an observer
+func observeLandline(parsed string, iso3166 ISO3166, valid bool) {
+	if o := observer.Load(); o != nil {
+		(*o).OnParse(iso3166.Alpha2, valid, valid && validateMobileISO3166(parsed, iso3166))
+	}
+}
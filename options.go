@@ -0,0 +1,42 @@
+package phonenumber
+
+import "strings"
+
+// Options configure ParseWithOptions, the zero value parses like Parse without country
+type Options struct {
+	// DefaultCountry the number is parsed by, as alpha2, alpha3 or country name
+	DefaultCountry string
+	// AllowLandline accepts the landline numbers too, like ParseWithLandLine
+	AllowLandline bool
+	// Strict rejects the numbers given with '+' and the country code of another country, like ParseStrict
+	Strict bool
+	// KeepExtension returns the extension of the number in ParseResult.Extension
+	KeepExtension bool
+	// RejectFake rejects the obvious placeholder numbers, like IsLikelyFake
+	RejectFake bool
+}
+
+// ParseWithOptions parses the number with the options and returns the full result of the parsing.
+// E164 is empty when the number is not accepted by the options, Valid and Mobile
+// still describe the number itself.
+func ParseWithOptions(number string, opts Options) ParseResult {
+	raw := number
+	number, ext := splitExtension(number)
+	result := newParseResult(parseInternal(number, opts.DefaultCountry))
+	result.RawInput = raw
+	observeParse(result.ISO3166, result.Valid, result.Mobile)
+	if opts.KeepExtension && result.Valid {
+		result.Extension = ext
+	}
+
+	if !result.Mobile && !opts.AllowLandline {
+		result.E164 = ""
+	}
+	if opts.Strict && isInternational(number) && !strings.HasPrefix(Normalize(number), result.ISO3166.CountryCode) {
+		result.E164 = ""
+	}
+	if opts.RejectFake && result.Valid && isFakeDigits(nationalNumber(result.E164, result.ISO3166)) {
+		result.E164 = ""
+	}
+	return result
+}
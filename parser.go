@@ -0,0 +1,92 @@
+package phonenumber
+
+import "sync"
+
+// Parser parses numbers with a country table and a regexp cache of its own, so the
+// countries registered or overridden on it never affect the package-level functions
+// or the other parsers, and the other way around. The observer set by SetObserver
+// is not notified. It is safe for concurrent use.
+type Parser struct {
+	lock    sync.RWMutex
+	datas   []ISO3166
+	index   *countryIndex
+	regexps *regexpCache
+}
+
+// NewParser returns a parser with the built-in country table, without the countries
+// registered or overridden on the package
+func NewParser() *Parser {
+	iso3166Once.Do(loadISO3166)
+	iso3166Lock.RLock()
+	defer iso3166Lock.RUnlock()
+	return &Parser{datas: iso3166Builtin, index: newCountryIndex(iso3166Builtin), regexps: newRegexpCache()}
+}
+
+// GetISO3166 is GetISO3166 of the parser country table
+func (p *Parser) GetISO3166() []ISO3166 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.datas
+}
+
+// RegisterCountry is RegisterCountry for the parser only
+func (p *Parser) RegisterCountry(iso3166 ISO3166) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	datas, err := registerCountry(p.datas, iso3166)
+	if err != nil {
+		return err
+	}
+	p.datas = datas
+	p.index = newCountryIndex(datas)
+	return nil
+}
+
+// OverrideCountry is OverrideCountry for the parser only
+func (p *Parser) OverrideCountry(alpha2 string, iso3166 ISO3166) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	datas, err := overrideCountry(p.datas, alpha2, iso3166)
+	if err != nil {
+		return err
+	}
+	p.datas = datas
+	p.index = newCountryIndex(datas)
+	return nil
+}
+
+// Parse is Parse mobile number by country of the parser table
+func (p *Parser) Parse(number string, country string) string {
+	parsed, iso3166 := p.parseInternal(number, country)
+	if isMobileWithCache(parsed, iso3166, p.regexps) {
+		return parsed
+	}
+	return ""
+}
+
+// ParseWithLandLine is ParseWithLandLine by country of the parser table
+func (p *Parser) ParseWithLandLine(number string, country string) string {
+	parsed, iso3166 := p.parseInternal(number, country)
+	if isLandlineWithCache(parsed, iso3166, p.regexps) {
+		return parsed
+	}
+	return ""
+}
+
+// ParseWithFlags is ParseWithFlags by country of the parser table
+func (p *Parser) ParseWithFlags(number string, country string) (parsed string, valid bool, mobile bool) {
+	var iso3166 ISO3166
+	parsed, iso3166 = p.parseInternal(number, country)
+	valid, mobile = validatePhoneWithCache(parsed, iso3166, p.regexps)
+	if !valid {
+		parsed = ""
+	}
+	return
+}
+
+func (p *Parser) parseInternal(number string, country string) (string, ISO3166) {
+	p.lock.RLock()
+	datas, index := p.datas, p.index
+	p.lock.RUnlock()
+	return iso3166Parser(index.find(country, datas))(number)
+}
@@ -0,0 +1,84 @@
+package phonenumber
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	p := NewParser()
+	for _, tt := range mobWithLLFormatTests {
+		if number, expected := p.Parse(tt.input, tt.country), Parse(tt.input, tt.country); number != expected {
+			t.Errorf("Parser.Parse(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, expected, number)
+		}
+		if number, expected := p.ParseWithLandLine(tt.input, tt.country), ParseWithLandLine(tt.input, tt.country); number != expected {
+			t.Errorf("Parser.ParseWithLandLine(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, expected, number)
+		}
+		number, valid, mobile := p.ParseWithFlags(tt.input, tt.country)
+		if expected, expectedValid, expectedMobile := ParseWithFlags(tt.input, tt.country); number != expected || valid != expectedValid || mobile != expectedMobile {
+			t.Errorf("Parser.ParseWithFlags(number=`%s`, country=`%s`): expected (`%s`, %t, %t), actual (`%s`, %t, %t)", tt.input, tt.country, expected, expectedValid, expectedMobile, number, valid, mobile)
+		}
+	}
+
+	p.regexps.lock.RLock()
+	defer p.regexps.lock.RUnlock()
+	if len(p.regexps.m) == 0 {
+		t.Errorf("Parser.ParseWithLandLine(): expected the regexps to be cached by the parser")
+	}
+}
+
+func TestParserIsolated(t *testing.T) {
+	p := NewParser()
+	lv := getISO3166ByCountry("LV")
+	lv.MobileBeginWith = []string{"6"}
+	if err := p.OverrideCountry("LV", lv); err != nil {
+		t.Fatalf("Parser.OverrideCountry(LV): unexpected error `%v`", err)
+	}
+	if number := p.Parse("25 641 580", "LV"); number != "" {
+		t.Errorf("Parser.Parse(number=`25 641 580`, country=`LV`): expected ``, actual `%s`", number)
+	}
+	if number := p.Parse("67 881 727", "LV"); number != "37167881727" {
+		t.Errorf("Parser.Parse(number=`67 881 727`, country=`LV`): expected `37167881727`, actual `%s`", number)
+	}
+	if number := Parse("25 641 580", "LV"); number != "37125641580" {
+		t.Errorf("Parse(number=`25 641 580`, country=`LV`): expected `37125641580`, actual `%s`", number)
+	}
+	if number := NewParser().Parse("25 641 580", "LV"); number != "37125641580" {
+		t.Errorf("NewParser().Parse(number=`25 641 580`, country=`LV`): expected `37125641580`, actual `%s`", number)
+	}
+
+	zx := ISO3166{Alpha2: "ZX", Alpha3: "ZXX", CountryCode: "997", MobileBeginWith: []string{"5"}, PhoneNumberLengths: []int{9}}
+	if err := p.RegisterCountry(zx); err != nil {
+		t.Fatalf("Parser.RegisterCountry(ZX): unexpected error `%v`", err)
+	}
+	if number := p.Parse("512 345 678", "ZX"); number != "997512345678" {
+		t.Errorf("Parser.Parse(number=`512 345 678`, country=`ZX`): expected `997512345678`, actual `%s`", number)
+	}
+	if number := Parse("512 345 678", "ZX"); number != "" {
+		t.Errorf("Parse(number=`512 345 678`, country=`ZX`): expected ``, actual `%s`", number)
+	}
+	if err := p.OverrideCountry("ZY", zx); !errors.Is(err, ErrUnknownCountry) {
+		t.Errorf("Parser.OverrideCountry(ZY): expected error `%v`, actual `%v`", ErrUnknownCountry, err)
+	}
+}
+
+func TestE164Limit(t *testing.T) {
+	p := NewParser()
+	oversized := ISO3166{Alpha2: "ZW", Alpha3: "ZWE", CountryCode: "263", MobileBeginWith: []string{"7"}, PhoneNumberLengths: []int{9, 13}}
+	if err := p.OverrideCountry("ZW", oversized); err != nil {
+		t.Fatalf("Parser.OverrideCountry(ZW): unexpected error `%v`", err)
+	}
+	if number := p.Parse("+263 71 234 5678", "ZW"); number != "263712345678" {
+		t.Errorf("Parser.Parse(number=`+263 71 234 5678`, country=`ZW`): expected `263712345678`, actual `%s`", number)
+	}
+	// 16 digits with the country code, even though the length is configured
+	if number := p.ParseWithLandLine("+263 7123 4567 89012", "ZW"); number != "" {
+		t.Errorf("Parser.ParseWithLandLine(number=`+263 7123 4567 89012`, country=`ZW`): expected ``, actual `%s`", number)
+	}
+
+	for number, expected := range map[string]bool{"+371 25 641 580": true, "+263 7123 4567 8901": true, "+263 7123 4567 89012": false, "": true} {
+		if within := IsWithinE164Limit(number); within != expected {
+			t.Errorf("IsWithinE164Limit(number=`%s`): expected `%t`, actual `%t`", number, expected, within)
+		}
+	}
+}
@@ -1,31 +1,277 @@
 package phonenumber
 
 import (
+	"errors"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
 )
 
 var (
-	digitsOnlyRegexp         = regexp.MustCompile(`\D`)
-	leadZeroRegexp           = regexp.MustCompile(`^0+`)
-	rusLocalePrefixRegexp    = regexp.MustCompile(`^8+`)
-	rusLocaleMobPrefixRegexp = regexp.MustCompile(`^89`)
+	leadZeroRegexp  = regexp.MustCompile(`^0+`)
+	extensionRegexp = regexp.MustCompile(`(?i)\s*(?:ext(?:ension)?\.?|x|#|[пд]об\.?)\s*(\d+)\s*$`)
+	// vanityExtensionRegexp is the extension of the vanity numbers, where x is a letter of the number
+	vanityExtensionRegexp = regexp.MustCompile(`(?i)(?:\bext(?:ension)?\.?|#)\s*\d+\s*$`)
+)
+
+var (
+	// ErrUnknownCountry is returned when the country can not be resolved
+	ErrUnknownCountry = errors.New("phonenumber: unknown country")
+	// ErrInvalidLength is returned when the number length does not match the country
+	ErrInvalidLength = errors.New("phonenumber: invalid number length")
+	// ErrNotMobile is returned when the number is valid but is not a mobile number
+	ErrNotMobile = errors.New("phonenumber: not a mobile number")
 )
 
 // Parse mobile number by country
 func Parse(number string, country string) string {
 	parsed, iso3166 := parseInternal(number, country)
-	if validateMobileISO3166(parsed, iso3166) {
+	valid := validateMobileISO3166(parsed, iso3166)
+	observeParse(iso3166, valid, valid)
+	if valid {
+		return parsed
+	}
+	return ""
+}
+
+// ParseOrDefault is Parse mobile number by country, returning the fallback for invalid numbers
+func ParseOrDefault(number string, country string, fallback string) string {
+	if parsed := Parse(number, country); parsed != "" {
+		return parsed
+	}
+	return fallback
+}
+
+// MustParse is Parse mobile number by country, which panics for invalid numbers.
+// It simplifies the initialization of the variables holding known numbers, and tests.
+func MustParse(number string, country string) string {
+	parsed := Parse(number, country)
+	if parsed == "" {
+		panic("phonenumber: MustParse(`" + number + "`, `" + country + "`): invalid number")
+	}
+	return parsed
+}
+
+// ParseInto is Parse mobile number by country, writing the parsed number into dst
+// instead of allocating a string. It returns the number of bytes written and whether
+// the number is valid. Nothing is written for invalid numbers, or when dst is too short.
+func ParseInto(dst []byte, number string, country string) (n int, valid bool) {
+	if strings.ContainsAny(number, "xX#пПдД") {
+		number, _ = splitExtension(number)
+	}
+
+	iso3166 := getISO3166ByCountry(country)
+	var buf [32]byte
+	parsed := appendISO3166(buf[:0], number, iso3166)
+	valid = isMobileDigits(parsed, iso3166)
+	observeParse(iso3166, valid, valid)
+	if !valid || len(dst) < len(parsed) {
+		return 0, false
+	}
+	return copy(dst, parsed), true
+}
+
+// Normalize removes any non-digit character from the number, included the +.
+// No country specific logic or validation is applied.
+// Unicode digits, e.g. fullwidth or Arabic-Indic, are converted to ASCII digits.
+func Normalize(number string) string {
+	var b strings.Builder
+	b.Grow(len(number))
+	for _, r := range number {
+		if d, ok := asciiDigit(r); ok {
+			b.WriteByte(d)
+		}
+	}
+	return b.String()
+}
+
+// asciiDigit returns the ASCII digit of any Unicode decimal digit
+func asciiDigit(r rune) (byte, bool) {
+	if r >= '0' && r <= '9' {
+		return byte(r), true
+	}
+	if r < utf8.RuneSelf || !unicode.IsDigit(r) {
+		return 0, false
+	}
+
+	// The Unicode decimal digits are grouped by 10 from zero to nine
+	zero := r
+	for unicode.IsDigit(zero - 1) {
+		zero--
+	}
+	return byte('0' + (r-zero)%10), true
+}
+
+// ParseWithExtension is Parse mobile number by country, returning the extension
+// (e.g. "ext. 123", "x123", "#123") separately from the parsed number.
+func ParseWithExtension(number string, country string) (parsed string, ext string) {
+	number, ext = splitExtension(number)
+	parsed = Parse(number, country)
+	if parsed == "" {
+		ext = ""
+	}
+	return
+}
+
+// ParseWithHint is Parse mobile number, where the country is taken from the number itself
+// when it starts with '+', and the country hint is used for numbers without it.
+func ParseWithHint(number string, countryHint string) string {
+	parsed, iso3166 := parseInternalWithHint(number, countryHint)
+	valid := validateMobileISO3166(parsed, iso3166)
+	observeParse(iso3166, valid, valid)
+	if valid {
+		return parsed
+	}
+	return ""
+}
+
+// ParseTagged is ParseWithHint for the number prefixed with its country, e.g.
+// "United States +1 202 555 0143" or "UK: 07700 900000". The prefix is everything before
+// the number and is only consumed when it is an alpha2, alpha3, name or alias of a country,
+// an empty string is returned otherwise. The numbers without a prefix are parsed when they start with '+',
+// the default country is not used.
+func ParseTagged(s string) string {
+	start := strings.IndexFunc(s, func(r rune) bool {
+		_, digit := asciiDigit(r)
+		return digit || r == '+' || r == '('
+	})
+	if start == -1 {
+		return ""
+	}
+
+	tag := strings.TrimRightFunc(s[:start], func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	})
+	if tag == "" {
+		if !isInternational(s[start:]) {
+			return ""
+		}
+		return ParseWithHint(s[start:], "")
+	}
+	iso3166 := getISO3166ByCountry(tag)
+	if iso3166.IsZero() {
+		return ""
+	}
+	return ParseWithHint(s[start:], iso3166.Alpha2)
+}
+
+// ParseStrict is Parse mobile number by country, where the number given with '+'
+// must carry the country code of the country, e.g. +44 numbers are rejected for US.
+func ParseStrict(number string, country string) string {
+	parsed, iso3166 := parseInternal(number, country)
+	valid := validateMobileISO3166(parsed, iso3166)
+	if isInternational(number) && !strings.HasPrefix(Normalize(number), iso3166.CountryCode) {
+		valid = false
+	}
+	observeParse(iso3166, valid, valid)
+	if valid {
 		return parsed
 	}
 	return ""
 }
 
+// keypadDigits maps the letters A-Z to the phone keypad digits
+const keypadDigits = "22233344455566677778889999"
+
+// ParseVanity parses the vanity number by country, e.g. 1-800-FLOWERS, where the letters
+// are converted to the phone keypad digits. Mobile and landline numbers are accepted,
+// an empty string is returned for invalid numbers. Only the extensions given with ext or #
+// are dropped, x is a letter of the number.
+func ParseVanity(number string, country string) string {
+	if loc := vanityExtensionRegexp.FindStringIndex(number); loc != nil {
+		number = number[:loc[0]]
+	}
+	number = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			return rune(keypadDigits[r-'A'])
+		case r >= 'a' && r <= 'z':
+			return rune(keypadDigits[r-'a'])
+		}
+		return r
+	}, number)
+	return ParseWithLandLine(number, country)
+}
+
+// ParseE164Only is Parse mobile number given in the international format, e.g. +12025550143,
+// where the country is detected from the number. Numbers without '+' are invalid.
+func ParseE164Only(number string) string {
+	if !isInternational(number) {
+		return ""
+	}
+	return ParseWithHint(number, "")
+}
+
+// ParseE164 is Parse mobile number by country, returning an error
+// describing why the number was rejected.
+func ParseE164(number string, country string) (string, error) {
+	parsed, iso3166 := parseInternal(number, country)
+	valid, mobile := validatePhoneISO3166(parsed, iso3166)
+	observeParse(iso3166, valid && mobile, mobile)
+	if iso3166.IsZero() {
+		return "", ErrUnknownCountry
+	}
+	if !valid {
+		return "", ErrInvalidLength
+	}
+	if !mobile {
+		return "", ErrNotMobile
+	}
+	return parsed, nil
+}
+
+// maxE164Digits is the ITU limit of the digits in a number, the country code included
+const maxE164Digits = 15
+
+// IsWithinE164Limit reports whether the number has at most 15 digits, the country code included.
+// Any non-digit character is ignored.
+func IsWithinE164Limit(number string) bool {
+	return len(Normalize(number)) <= maxE164Digits
+}
+
+// IsE164 reports whether the number is strictly in the E.164 format, e.g. +12025550143:
+// '+' followed by up to 15 digits without separators, starting with a known country code.
+func IsE164(number string) bool {
+	digits := strings.TrimPrefix(number, "+")
+	if len(digits) == len(number) || len(digits) == 0 || len(digits) > maxE164Digits || digits[0] == '0' {
+		return false
+	}
+	for k := 0; k < len(digits); k++ {
+		if digits[k] < '0' || digits[k] > '9' {
+			return false
+		}
+	}
+	for _, i := range GetISO3166() {
+		if strings.HasPrefix(digits, i.CountryCode) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseNational is Parse mobile number by country, returning the national significant number
+// without the country code, e.g. 25641580 for +371 25 641 580
+func ParseNational(number string, country string) string {
+	parsed, iso3166 := parseInternal(number, country)
+	valid := validateMobileISO3166(parsed, iso3166)
+	observeParse(iso3166, valid, valid)
+	if valid {
+		return nationalNumber(parsed, iso3166)
+	}
+	return ""
+}
+
 // ParseWithLandLine is Parse mobile and landline number by country
 func ParseWithLandLine(number string, country string) string {
 	parsed, iso3166 := parseInternal(number, country)
-	if validateLandlineISO3166(parsed, iso3166) {
+	valid := validateLandlineISO3166(parsed, iso3166)
+	observeLandline(parsed, iso3166, valid)
+	if valid {
 		return parsed
 	}
 	return ""
@@ -37,30 +283,276 @@ func ParseWithFlags(number string, country string) (parsed string, valid bool, m
 	var iso3166 ISO3166
 	parsed, iso3166 = parseInternal(number, country)
 	valid, mobile = validatePhoneISO3166(parsed, iso3166)
+	observeParse(iso3166, valid, mobile)
 	if !valid {
 		parsed = ""
 	}
 	return
 }
 
+// ParseMobileOrLandline is ParseWithLandLine also reporting whether the number is mobile.
+// An empty string is returned for invalid numbers.
+func ParseMobileOrLandline(number string, country string) (parsed string, mobile bool) {
+	parsed, _, mobile = ParseWithFlags(number, country)
+	return parsed, mobile
+}
+
+// ParseSplit is ParseWithFlags returning the country code and the national number separately,
+// e.g. 371 and 25641580 for +371 25 641 580. Both are empty for invalid numbers.
+func ParseSplit(number string, country string) (countryCode string, national string, valid bool, mobile bool) {
+	parsed, iso3166 := parseInternal(number, country)
+	valid, mobile = validatePhoneISO3166(parsed, iso3166)
+	observeParse(iso3166, valid, mobile)
+	if !valid {
+		return "", "", false, false
+	}
+	return iso3166.CountryCode, nationalNumber(parsed, iso3166), valid, mobile
+}
+
+// IsValidMobile reports whether the number is a valid mobile number of the country
+func IsValidMobile(number string, country string) bool {
+	parsed, iso3166 := parseInternal(number, country)
+	return validateMobileISO3166(parsed, iso3166)
+}
+
+// IsValidLandline reports whether the number is a valid mobile or landline number of the country
+func IsValidLandline(number string, country string) bool {
+	parsed, iso3166 := parseInternal(number, country)
+	return validateLandlineISO3166(parsed, iso3166)
+}
+
+// IsPossibleNumber reports whether the number could become a valid mobile number
+// of the country with more digits: it must not be longer than the longest number
+// of the country and must begin with one of its mobile prefixes.
+func IsPossibleNumber(number string, country string) bool {
+	nationals, iso3166 := possibleNationalNumbers(number, country)
+	for _, national := range nationals {
+		if isPossibleNationalNumber(national, iso3166) {
+			return true
+		}
+	}
+	return false
+}
+
+// NumberState tells how far the partially entered number is from a valid mobile number
+type NumberState int
+
+const (
+	// Invalid numbers can not become valid with more digits
+	Invalid NumberState = iota
+	// Possible numbers have a mobile prefix, but too few digits yet
+	Possible
+	// Valid mobile numbers
+	Valid
+	// TooLong numbers have more digits than the longest mobile number of the country
+	TooLong
+)
+
+// ParseWithState is Parse mobile number by country, returning the state of the number
+// as well, e.g. to tell "keep typing" from "invalid" in the UI.
+// The parsed number is only returned for valid numbers.
+func ParseWithState(number string, country string) (string, NumberState) {
+	parsed, iso3166 := parseInternal(number, country)
+	valid := validateMobileISO3166(parsed, iso3166)
+	observeParse(iso3166, valid, valid)
+	if valid {
+		return parsed, Valid
+	}
+
+	nationals, iso3166 := possibleNationalNumbers(number, country)
+	longest := maxInt(mobileLengths(iso3166))
+	state := Invalid
+	for _, national := range nationals {
+		switch {
+		case national == "" || iso3166.IsZero():
+			continue
+		case len(national) > longest:
+			if state == Invalid {
+				state = TooLong
+			}
+		case len(national) < longest && isPossibleNationalNumber(national, iso3166):
+			return "", Possible
+		}
+	}
+	return "", state
+}
+
+// possibleNationalNumbers returns the national numbers the partially entered number may stand for
+func possibleNationalNumbers(number string, country string) ([]string, ISO3166) {
+	number, _ = splitExtension(number)
+	number = stripSpaces(number)
+	international := isInternational(number)
+	iso3166 := getISO3166ByCountry(country)
+	number = Normalize(number)
+	if international {
+		if !strings.HasPrefix(number, iso3166.CountryCode) {
+			return nil, iso3166
+		}
+		national := strings.TrimPrefix(number, iso3166.CountryCode)
+		if keepsLeadingZero(iso3166) {
+			return []string{national}, iso3166
+		}
+		return []string{strings.TrimPrefix(national, getNationalPrefix(iso3166))}, iso3166
+	}
+
+	national := number
+	if !keepsLeadingZero(iso3166) {
+		national = leadZeroRegexp.ReplaceAllString(strings.TrimPrefix(number, getNationalPrefix(iso3166)), "")
+	}
+	if strings.HasPrefix(national, iso3166.CountryCode) {
+		return []string{national, strings.TrimPrefix(national, iso3166.CountryCode)}, iso3166
+	}
+	return []string{national}, iso3166
+}
+
+func isPossibleNationalNumber(national string, iso3166 ISO3166) bool {
+	if national == "" || len(national) > maxInt(iso3166.PhoneNumberLengths) {
+		return false
+	}
+	if iso3166.AnyMobilePrefix {
+		return true
+	}
+	for _, w := range iso3166.MobileBeginWith {
+		if w != "" && (strings.HasPrefix(national, w) || strings.HasPrefix(w, national)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseBestEffort is Parse mobile number by country, which recovers the numbers too long
+// to be valid, e.g. pasted twice, by dropping the trailing digits one by one until a valid
+// number is left. The flag reports whether the number was truncated.
+func ParseBestEffort(number string, country string) (string, bool) {
+	number, _ = splitExtension(number)
+	parsed, iso3166 := parseInternal(number, country)
+	if validateMobileISO3166(parsed, iso3166) {
+		observeParse(iso3166, true, true)
+		return parsed, false
+	}
+
+	lengths := mobileLengths(iso3166)
+	if len(lengths) == 0 {
+		observeParse(iso3166, false, false)
+		return "", false
+	}
+	tooLong := false
+	nationals, _ := possibleNationalNumbers(number, country)
+	for _, national := range nationals {
+		tooLong = tooLong || len(national) > maxInt(lengths)
+	}
+
+	digits := Normalize(number)
+	prefix := ""
+	if isInternational(number) {
+		prefix = "+"
+	}
+	for n := len(digits) - 1; tooLong && n >= slices.Min(lengths); n-- {
+		if truncated, _ := parseInternal(prefix+digits[:n], country); validateMobileISO3166(truncated, iso3166) {
+			observeParse(iso3166, true, true)
+			return truncated, true
+		}
+	}
+	observeParse(iso3166, false, false)
+	return "", false
+}
+
+// ParseCandidates parses the number without country. Every country where the number
+// is a valid mobile number is tried, and all the valid candidates are returned.
+// International numbers are only tried against countries with the matching country code.
+// The Observer is not notified.
+func ParseCandidates(number string) []ParseResult {
+	raw := number
+	number, _ = splitExtension(number)
+	international := isInternational(number)
+	digits := Normalize(number)
+	result := []ParseResult{}
+	for _, i := range GetISO3166() {
+		if international && !strings.HasPrefix(digits, i.CountryCode) {
+			continue
+		}
+		parsed := parseISO3166(number, i)
+		if validateMobileISO3166(parsed, i) {
+			candidate := newParseResult(parsed, i)
+			candidate.RawInput = raw
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
+
+// ParseResult holds everything computed while parsing a number.
+// It is populated even when the number is invalid, in which case E164 is empty.
+type ParseResult struct {
+	E164           string
+	Valid          bool
+	Mobile         bool
+	ISO3166        ISO3166
+	NationalNumber string
+	// MatchedLength is the one of the country PhoneNumberLengths the number matched, 0 for invalid numbers
+	MatchedLength int
+	// Extension of the number, only set by ParseWithOptions with KeepExtension
+	Extension string
+	// RawInput is the number as given, verbatim, e.g. for audit trails
+	RawInput string
+}
+
+// ParseDetailed parses the number like ParseWithFlags and returns the full result
+// of the parsing, including the resolved country and the national number.
+func ParseDetailed(number string, country string) ParseResult {
+	result := newParseResult(parseInternal(number, country))
+	result.RawInput = number
+	observeParse(result.ISO3166, result.Valid, result.Mobile)
+	return result
+}
+
+func newParseResult(parsed string, iso3166 ISO3166) ParseResult {
+	valid, mobile := validatePhoneISO3166(parsed, iso3166)
+
+	result := ParseResult{
+		Valid:          valid,
+		Mobile:         mobile,
+		ISO3166:        iso3166,
+		NationalNumber: nationalNumber(parsed, iso3166),
+	}
+	if valid {
+		result.E164 = parsed
+		result.MatchedLength = len(result.NationalNumber)
+	}
+	return result
+}
+
+// MatchedLength returns which of the country PhoneNumberLengths the number matched,
+// and whether the number is valid.
+func MatchedLength(number string, country string) (int, bool) {
+	result := ParseDetailed(number, country)
+	return result.MatchedLength, result.Valid
+}
+
+// NationalNumberLength returns the number of digits left after the country code is removed.
+// 0 is returned for an unknown country, or when the number is shorter than the country code.
+func NationalNumberLength(number string, country string) int {
+	parsed, iso3166 := parseInternal(number, country)
+	if iso3166.IsZero() || len(parsed) <= len(iso3166.CountryCode) {
+		return 0
+	}
+	return len(nationalNumber(parsed, iso3166))
+}
+
 // GetISO3166ByNumber ...
 func GetISO3166ByNumber(number string, withLandLine bool) ISO3166 {
 	iso3166 := ISO3166{}
-	for _, i := range GetISO3166() {
+	for _, i := range getISO3166ByNumberPrefix(number) {
 		r := getRegexpByCountryCode(i.CountryCode)
 		for _, l := range i.PhoneNumberLengths {
 			if r.MatchString(number) && len(number) == len(i.CountryCode)+l {
-				// Check match with mobile codes
-				for _, w := range i.MobileBeginWith {
-					rm := getRegexpByCountryCode(i.CountryCode + w)
-					if rm.MatchString(number) {
-						// Match by mobile codes
-						return i
-					}
+				// Match by mobile codes
+				if hasMobilePrefix(number[len(i.CountryCode):], i) {
+					return i
 				}
 
 				// Match by country code only for landline numbers only
-				if withLandLine {
+				if withLandLine && matchesNANPAreaCode(number, i) {
 					iso3166 = i
 					break
 				}
@@ -70,128 +562,513 @@ func GetISO3166ByNumber(number string, withLandLine bool) ISO3166 {
 	return iso3166
 }
 
-// GetISO3166ByMobileNumber ...
+// GetISO3166ByMobileNumber returns the countries where the number, without country code,
+// has a valid mobile prefix and length. The national prefix of the country, e.g. 0 of 07933846223
+// in the United Kingdom, is removed before the number is matched. Every country is returned once, the countries
+// with the longest (most specific) matching prefix come first.
+// The countries with AnyMobilePrefix are never returned, as any number would match them.
 func GetISO3166ByMobileNumber(number string) []ISO3166 {
 	result := []ISO3166{}
+	prefixLengths := map[string]int{}
+	digits := []byte(number)
 	for _, i := range GetISO3166() {
-		for _, l := range i.PhoneNumberLengths {
-			if len(number) == l {
-				for _, w := range i.MobileBeginWith {
-					if w != "" && strings.HasPrefix(number, w) {
-						result = append(result, i)
-					}
-				}
+		national := number
+		if nationalPrefix := getNationalPrefix(i); !i.KeepsLeadingZero && hasNationalPrefix(digits, nationalPrefix, i) {
+			national = number[len(nationalPrefix):]
+		}
+		if indexOfInt(len(national), i.PhoneNumberLengths) == -1 {
+			continue
+		}
+		longest := 0
+		for _, w := range i.MobileBeginWith {
+			if w != "" && len(w) > longest && strings.HasPrefix(national, w) {
+				longest = len(w)
 			}
 		}
+		if longest == 0 {
+			continue
+		}
+		if _, exists := prefixLengths[i.Alpha3]; exists {
+			prefixLengths[i.Alpha3] = maxInt([]int{prefixLengths[i.Alpha3], longest})
+			continue
+		}
+		prefixLengths[i.Alpha3] = longest
+		result = append(result, i)
 	}
+
+	sort.SliceStable(result, func(a, b int) bool {
+		return prefixLengths[result[a].Alpha3] > prefixLengths[result[b].Alpha3]
+	})
 	return result
 }
 
 func parseInternal(number string, country string) (string, ISO3166) {
-	number = strings.Replace(number, " ", "", -1)
-	country = strings.Replace(country, " ", "", -1)
+	return countryParser(country)(number)
+}
+
+// countryParser resolves the country once and returns a function
+// parsing numbers for this country.
+func countryParser(country string) func(number string) (string, ISO3166) {
+	return iso3166Parser(getISO3166ByCountry(country))
+}
+
+// iso3166Parser returns a function parsing numbers for the already resolved country
+func iso3166Parser(iso3166 ISO3166) func(number string) (string, ISO3166) {
+	return func(number string) (string, ISO3166) {
+		number, _ = splitExtension(number)
+		number = stripSpaces(number)
+		return parseISO3166(number, iso3166), iso3166
+	}
+}
+
+func parseInternalWithHint(number string, countryHint string) (string, ISO3166) {
+	if !isInternational(number) {
+		return parseInternal(number, countryHint)
+	}
+	return parseInternational(number)
+}
+
+// isInternational reports whether the number is given with the leading '+'.
+// Any whitespace before it is ignored, and one or more '+', e.g. "++44" or "+ + 44",
+// are the same single international indicator, as Normalize removes them all.
+func isInternational(number string) bool {
+	for _, r := range number {
+		if !unicode.IsSpace(r) {
+			return r == '+'
+		}
+	}
+	return false
+}
+
+// parseInternational parses the number with the country matching its country code.
+// Countries where the number is a valid mobile number are preferred over landline matches,
+// the global services, e.g. +800, are only tried when no country matches.
+func parseInternational(number string) (string, ISO3166) {
+	number, _ = splitExtension(number)
+	digits := Normalize(number)
 
-	if strings.HasPrefix(number, "+") {
-		if country == "" {
-			return "", ISO3166{}
+	landline, landlineISO3166 := "", ISO3166{}
+	for _, i := range getISO3166ByNumberPrefix(digits) {
+		parsed := parseISO3166(digits, i)
+		valid, mobile := validatePhoneISO3166(parsed, i)
+		if mobile {
+			return parsed, i
+		}
+		if valid && landline == "" {
+			landline, landlineISO3166 = parsed, i
 		}
 	}
+	if landline != "" {
+		return landline, landlineISO3166
+	}
+	return digits, getNonGeographicByNumber(digits)
+}
+
+func parseISO3166(number string, iso3166 ISO3166) string {
+	var buf [32]byte
+	return string(appendISO3166(buf[:0], number, iso3166))
+}
+
+// appendISO3166 parses the number by country and appends the result to dst
+func appendISO3166(dst []byte, number string, iso3166 ISO3166) []byte {
+	keepLeadingZero := keepsLeadingZero(iso3166)
+	international := isInternational(number)
 
 	// remove any non-digit character, included the +
-	number = digitsOnlyRegexp.ReplaceAllString(number, "")
+	var buf [32]byte
+	digits := buf[:0]
+	// the optional trunk prefix printed for the domestic callers, e.g. +44 (0) 20, is never dialed
+	if k := strings.Index(number, "(0)"); k != -1 && !keepLeadingZero {
+		digits = appendDigits(digits, number[:k])
+		number = number[k+len("(0)"):]
+	}
+	digits = appendDigits(digits, number)
 
-	iso3166 := getISO3166ByCountry(country)
+	// the international 00 prefix is removed like the +, so the national prefix following
+	// the country code is removed once and for all. It is only dialed after the 00 where it is common,
+	// the number is invalid otherwise.
+	nationalPrefix := getNationalPrefix(iso3166)
+	if hasPrefix(digits, "00"+iso3166.CountryCode) {
+		digits = digits[2:]
+		international = true
+		if !keepLeadingZero && !iso3166.TrunkPrefixInMobile && hasNationalPrefix(digits[len(iso3166.CountryCode):], nationalPrefix, iso3166) {
+			return dst
+		}
+	}
+
+	// remove the national prefix, either leading or following the country code
+	national := false
+	if hasPrefix(digits, iso3166.CountryCode) {
+		withoutCountryCode := digits[len(iso3166.CountryCode):]
+		if !keepLeadingZero && hasNationalPrefix(withoutCountryCode, nationalPrefix, iso3166) {
+			withoutCountryCode = withoutCountryCode[len(nationalPrefix):]
+			// the zeros are removed all at once, like the leading ones, so parsing is idempotent
+			if strings.Trim(nationalPrefix, "0") == "" {
+				withoutCountryCode = trimLeft(withoutCountryCode, '0')
+			}
+			digits = append(digits[:len(iso3166.CountryCode)], withoutCountryCode...)
+		}
+	} else if !keepLeadingZero && hasNationalPrefix(digits, nationalPrefix, iso3166) {
+		digits = digits[len(nationalPrefix):]
+		// a single national prefix, not the international 00 one, is only dialed before national numbers
+		national = !hasPrefix(digits, "0")
+	}
+
+	if !keepLeadingZero {
+		digits = trimLeft(digits, '0')
+	}
 
-	// if number starts with country code and includes leading zero, remove the leading zero
-	if strings.HasPrefix(number, iso3166.CountryCode) {
-		withoutCountryCode := strings.Replace(number, iso3166.CountryCode, "", 1)
-		if strings.HasPrefix(withoutCountryCode, "0") {
-			withoutCountryCode = strings.Replace(withoutCountryCode, "0", "", 1)
+	// the national prefix kept after the country code, e.g. 0049 (0)151, is removed
+	// where it is common, as long as the number is only a valid mobile number without it
+	if iso3166.TrunkPrefixInMobile && hasPrefix(digits, iso3166.CountryCode) && hasPrefix(digits[len(iso3166.CountryCode):], nationalPrefix) && !isMobileDigits(digits, iso3166) {
+		var trunkBuf [32]byte
+		withoutTrunk := append(append(trunkBuf[:0], digits[:len(iso3166.CountryCode)]...), digits[len(iso3166.CountryCode)+len(nationalPrefix):]...)
+		if isMobileDigits(withoutTrunk, iso3166) {
+			digits = append(digits[:0], withoutTrunk...)
 		}
-		number = iso3166.CountryCode + withoutCountryCode
 	}
 
-	if indexOfString(iso3166.Alpha3, []string{"GAB", "CIV", "COG"}) == -1 {
-		number = leadZeroRegexp.ReplaceAllString(number, "")
+	if iso3166.MobileNinthDigit {
+		digits = insertNinthDigit(digits, iso3166)
+	}
+	if iso3166.MobileDomesticInfix != "" {
+		digits = internationalMobileForm(digits, iso3166)
+	}
+
+	// the number already starting with the country code is kept as is, so parsing is idempotent,
+	// unless it was given with the national prefix, e.g. 0491 in Germany. The number given with '+'
+	// and the country code never gets it twice, e.g. +86 21 1234 567 is too short, not 86 86 21 1234 567.
+	withCountryCode := !national && hasPrefix(digits, iso3166.CountryCode) && (international || indexOfInt(len(digits)-len(iso3166.CountryCode), iso3166.PhoneNumberLengths) != -1)
+	if !withCountryCode && indexOfInt(len(digits), iso3166.PhoneNumberLengths) != -1 {
+		dst = append(dst, iso3166.CountryCode...)
 	}
 
-	if iso3166.Alpha3 == "RUS" && len(number) == 11 && rusLocaleMobPrefixRegexp.MatchString(number) {
-		number = rusLocalePrefixRegexp.ReplaceAllString(number, "")
+	return append(dst, digits...)
+}
+
+// insertNinthDigit inserts the 9 after the area code of the legacy 8-digit mobile numbers,
+// given with or without the country code, as long as the number is mobile with it.
+// The subscriber numbers starting with 2-5 are landlines and never get the 9.
+func insertNinthDigit(digits []byte, iso3166 ISO3166) []byte {
+	const areaCode, subscriber = 2, 8
+	national := digits
+	if len(digits) == len(iso3166.CountryCode)+areaCode+subscriber && hasPrefix(digits, iso3166.CountryCode) {
+		national = digits[len(iso3166.CountryCode):]
 	}
-	if indexOfInt(len(number), iso3166.PhoneNumberLengths) != -1 {
-		number = iso3166.CountryCode + number
+	if len(national) != areaCode+subscriber || national[areaCode] < '6' {
+		return digits
 	}
 
-	return number, iso3166
+	var ninthBuf [32]byte
+	withNinth := append(append(ninthBuf[:0], iso3166.CountryCode...), national[:areaCode]...)
+	withNinth = append(append(withNinth, '9'), national[areaCode:]...)
+	if !isMobileDigits(withNinth, iso3166) {
+		return digits
+	}
+	if len(national) == len(digits) {
+		withNinth = withNinth[len(iso3166.CountryCode):]
+	}
+	return append(digits[:0], withNinth...)
 }
 
-func getISO3166ByCountry(country string) ISO3166 {
-	iso3166 := ISO3166{}
-	uppperCaseCountry := strings.ToUpper(country)
-	switch len(country) {
-	case 0:
-		iso3166 = GetISO3166()[0]
-	case 2:
-		for _, i := range GetISO3166() {
-			if i.Alpha2 == uppperCaseCountry {
-				iso3166 = i
-				break
-			}
+// internationalMobileForm turns the domestic mobile numbers, with the infix after the area code,
+// e.g. 11 15 1234-5678 in Argentina, into the international ones, e.g. 9 11 1234-5678,
+// given with or without the country code. The area code is the shortest one giving a mobile number.
+// The infix is only looked for after the country code, so the numbers already in the international
+// form, or the landlines, e.g. 54 215 249 8705, are kept as they are.
+func internationalMobileForm(digits []byte, iso3166 ISO3166) []byte {
+	const minAreaCode, maxAreaCode = 2, 4
+	national := digits
+	if hasPrefix(digits, iso3166.CountryCode) {
+		national = digits[len(iso3166.CountryCode):]
+	}
+	if indexOfInt(len(national)-len(iso3166.MobileDomesticInfix), iso3166.PhoneNumberLengths) == -1 {
+		return digits
+	}
+
+	for areaCode := minAreaCode; areaCode <= maxAreaCode && areaCode < len(national); areaCode++ {
+		if !hasPrefix(national[areaCode:], iso3166.MobileDomesticInfix) {
+			continue
 		}
-	case 3:
-		for _, i := range GetISO3166() {
-			if i.Alpha3 == uppperCaseCountry {
-				iso3166 = i
-				break
-			}
+
+		var mobileBuf [32]byte
+		mobile := append(append(mobileBuf[:0], iso3166.CountryCode...), iso3166.MobileInternationalPrefix...)
+		mobile = append(append(mobile, national[:areaCode]...), national[areaCode+len(iso3166.MobileDomesticInfix):]...)
+		if !isMobileDigits(mobile, iso3166) {
+			continue
 		}
-	default:
-		for _, i := range GetISO3166() {
-			if strings.ToUpper(i.CountryName) == uppperCaseCountry {
-				iso3166 = i
-				break
-			}
+		if len(national) == len(digits) {
+			mobile = mobile[len(iso3166.CountryCode):]
 		}
+		return append(digits[:0], mobile...)
 	}
-	return iso3166
+	return digits
+}
+
+// appendDigits appends the digits of the number to dst like Normalize, any other character is skipped
+func appendDigits(dst []byte, number string) []byte {
+	for _, r := range number {
+		if d, ok := asciiDigit(r); ok {
+			dst = append(dst, d)
+		}
+	}
+	return dst
+}
+
+// hasNationalPrefix reports whether the digits start with the national prefix to be removed.
+// The zeros are removed anyway, any other prefix, e.g. 8 in Russia, only when it is followed
+// by a national number of valid length, as it may be a part of the number itself.
+func hasNationalPrefix(digits []byte, nationalPrefix string, iso3166 ISO3166) bool {
+	if !hasPrefix(digits, nationalPrefix) {
+		return false
+	}
+	return strings.Trim(nationalPrefix, "0") == "" || indexOfInt(len(digits)-len(nationalPrefix), iso3166.PhoneNumberLengths) != -1
+}
+
+// hasPrefix is strings.HasPrefix for the digits being parsed
+func hasPrefix(digits []byte, prefix string) bool {
+	return len(digits) >= len(prefix) && string(digits[:len(prefix)]) == prefix
+}
+
+func trimLeft(digits []byte, c byte) []byte {
+	for len(digits) > 0 && digits[0] == c {
+		digits = digits[1:]
+	}
+	return digits
+}
+
+// stripSpaces removes any Unicode whitespace, e.g. tabs or non-breaking spaces
+func stripSpaces(s string) string {
+	if strings.IndexFunc(s, unicode.IsSpace) == -1 {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// getNationalPrefix returns the national prefix of the country, 0 when not set
+func getNationalPrefix(iso3166 ISO3166) string {
+	if iso3166.NationalPrefix == "" {
+		return "0"
+	}
+	return iso3166.NationalPrefix
+}
+
+// keepsLeadingZero reports whether the leading zero is a significant part of the national numbers of the country
+func keepsLeadingZero(iso3166 ISO3166) bool {
+	return iso3166.KeepsLeadingZero
+}
+
+// splitExtension removes the trailing extension from the number
+func splitExtension(number string) (string, string) {
+	loc := extensionRegexp.FindStringSubmatchIndex(number)
+	if loc == nil {
+		return number, ""
+	}
+	return number[:loc[0]], number[loc[2]:loc[3]]
+}
+
+// GetISO3166ByCountryCode returns all the countries sharing the calling code, e.g. "1" or "+44".
+// The countries are returned in the order of GetISO3166.
+func GetISO3166ByCountryCode(code string) []ISO3166 {
+	code = Normalize(code)
+	result := []ISO3166{}
+	if code == "" {
+		return result
+	}
+	for _, i := range GetISO3166() {
+		if i.CountryCode == code {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// nationalNumber returns the parsed number without the country code
+func nationalNumber(number string, iso3166 ISO3166) string {
+	return strings.TrimPrefix(number, iso3166.CountryCode)
+}
+
+// countryAliases contains the colloquial names and codes of the countries by their alpha2,
+// for the countries which are known by another name than the one in the table
+var countryAliases = map[string]string{
+	"UK":                               "GB",
+	"Great Britain":                    "GB",
+	"Britain":                          "GB",
+	"United States of America":         "US",
+	"South Korea":                      "KR",
+	"Russia":                           "RU",
+	"Iran":                             "IR",
+	"Syria":                            "SY",
+	"Vietnam":                          "VN",
+	"Laos":                             "LA",
+	"Moldova":                          "MD",
+	"Tanzania":                         "TZ",
+	"Venezuela":                        "VE",
+	"Brunei":                           "BN",
+	"Czechia":                          "CZ",
+	"North Macedonia":                  "MK",
+	"Macedonia":                        "MK",
+	"Taiwan":                           "TW",
+	"Ivory Coast":                      "CI",
+	"Cote d'Ivoire":                    "CI",
+	"DR Congo":                         "CD",
+	"Democratic Republic of the Congo": "CD",
+	"Micronesia":                       "FM",
+	"Palestine":                        "PS",
+	"Eswatini":                         "SZ",
+	"Cabo Verde":                       "CV",
+	"East Timor":                       "TL",
+	"Türkiye":                          "TR",
+	"Burma":                            "MM",
+	"UAE":                              "AE",
+	"Holland":                          "NL",
+}
+
+// defaultCountry is the country the empty country resolves to, there is none when nil
+var defaultCountry atomic.Pointer[string]
+
+// SetDefaultCountry sets the country, as alpha2, alpha3 or name, the empty country given
+// to the parse and validate functions resolves to. There is none by default, so the numbers
+// given without a country are invalid, unless the function takes the country from a number
+// starting with '+', e.g. ParseWithHint. An empty string removes the default country.
+func SetDefaultCountry(alpha2 string) {
+	defer iso3166Version.Add(1)
+	if stripSpaces(alpha2) == "" {
+		defaultCountry.Store(nil)
+		return
+	}
+	defaultCountry.Store(&alpha2)
+}
+
+// getISO3166ByCountry resolves the country by alpha2, alpha3, name or alias, in this order.
+// The country is case insensitive and any whitespace is ignored. The empty country
+// resolves to the one set by SetDefaultCountry.
+func getISO3166ByCountry(country string) ISO3166 {
+	if d := defaultCountry.Load(); d != nil && stripSpaces(country) == "" {
+		country = *d
+	}
+
+	iso3166Once.Do(loadISO3166)
+	iso3166Lock.RLock()
+	datas, index := iso3166Datas, iso3166Index
+	iso3166Lock.RUnlock()
+	return index.find(country, datas)
 }
 
 func validateMobileISO3166(number string, iso3166 ISO3166) bool {
-	if len(iso3166.PhoneNumberLengths) == 0 {
+	return IsMobileISO3166(number, iso3166)
+}
+
+// IsMobileISO3166 reports whether the already parsed number, e.g. 37125641580,
+// is a valid mobile number of the already resolved country.
+func IsMobileISO3166(number string, iso3166 ISO3166) bool {
+	return isMobileWithCache(number, iso3166, rCache)
+}
+
+// isMobileWithCache is IsMobileISO3166 with the regexps of the cache
+func isMobileWithCache(number string, iso3166 ISO3166, regexps *regexpCache) bool {
+	if len(iso3166.PhoneNumberLengths) == 0 || len(number) > maxE164Digits {
 		return false
 	}
 
-	r := getRegexpByCountryCode(iso3166.CountryCode)
+	r := regexps.get(iso3166.CountryCode)
 	number = r.ReplaceAllString(number, "")
-	for _, l := range iso3166.PhoneNumberLengths {
-		if l == len(number) {
-			for _, w := range iso3166.MobileBeginWith {
-				rm := getRegexpByCountryCode(w)
-				if rm.MatchString(number) {
-					return true
-				}
-			}
+	return indexOfInt(len(number), mobileLengths(iso3166)) != -1 && hasMobilePrefix(number, iso3166)
+}
+
+// hasMobilePrefix reports whether the national number starts with a mobile prefix of the country.
+// The empty prefixes are ignored, any number has a mobile prefix only with AnyMobilePrefix.
+func hasMobilePrefix(national string, iso3166 ISO3166) bool {
+	if iso3166.AnyMobilePrefix {
+		return true
+	}
+	for _, w := range iso3166.MobileBeginWith {
+		if w != "" && strings.HasPrefix(national, w) {
+			return true
 		}
 	}
 	return false
 }
 
-func validateLandlineISO3166(number string, iso3166 ISO3166) bool {
-	if len(iso3166.PhoneNumberLengths) == 0 {
+// isMobileDigits is IsMobileISO3166 for the digits being parsed, without regexps
+func isMobileDigits(digits []byte, iso3166 ISO3166) bool {
+	if len(iso3166.PhoneNumberLengths) == 0 || len(digits) > maxE164Digits {
 		return false
 	}
 
-	r := getRegexpByCountryCode(iso3166.CountryCode)
-	for _, l := range iso3166.PhoneNumberLengths {
-		if r.MatchString(number) && len(number) == len(iso3166.CountryCode)+l {
+	if hasPrefix(digits, iso3166.CountryCode) {
+		digits = digits[len(iso3166.CountryCode):]
+	}
+	if indexOfInt(len(digits), mobileLengths(iso3166)) == -1 {
+		return false
+	}
+	if iso3166.AnyMobilePrefix {
+		return true
+	}
+	for _, w := range iso3166.MobileBeginWith {
+		if w != "" && hasPrefix(digits, w) {
 			return true
 		}
 	}
 	return false
 }
 
+func validateLandlineISO3166(number string, iso3166 ISO3166) bool {
+	return IsLandlineISO3166(number, iso3166)
+}
+
+// IsLandlineISO3166 reports whether the already parsed number, e.g. 37167881727,
+// is a valid mobile or landline number of the already resolved country.
+func IsLandlineISO3166(number string, iso3166 ISO3166) bool {
+	return isLandlineWithCache(number, iso3166, rCache)
+}
+
+// isLandlineWithCache is IsLandlineISO3166 with the regexps of the cache
+func isLandlineWithCache(number string, iso3166 ISO3166, regexps *regexpCache) bool {
+	if len(iso3166.PhoneNumberLengths) == 0 || len(number) > maxE164Digits {
+		return false
+	}
+
+	r := regexps.get(iso3166.CountryCode)
+	if !r.MatchString(number) {
+		return false
+	}
+	for _, l := range fixedLineLengths(iso3166) {
+		if len(number) == len(iso3166.CountryCode)+l {
+			return true
+		}
+	}
+	return len(iso3166.FixedLineLengths) != 0 && isMobileWithCache(number, iso3166, regexps)
+}
+
+// mobileLengths returns the lengths of the national mobile numbers of the country
+func mobileLengths(iso3166 ISO3166) []int {
+	if len(iso3166.MobileLengths) != 0 {
+		return iso3166.MobileLengths
+	}
+	return iso3166.PhoneNumberLengths
+}
+
+// fixedLineLengths returns the lengths of the national fixed line numbers of the country
+func fixedLineLengths(iso3166 ISO3166) []int {
+	if len(iso3166.FixedLineLengths) != 0 {
+		return iso3166.FixedLineLengths
+	}
+	return iso3166.PhoneNumberLengths
+}
+
 func validatePhoneISO3166(number string, iso3166 ISO3166) (valid bool, mobile bool) {
-	if !validateLandlineISO3166(number, iso3166) {
+	return validatePhoneWithCache(number, iso3166, rCache)
+}
+
+// validatePhoneWithCache is validatePhoneISO3166 with the regexps of the cache
+func validatePhoneWithCache(number string, iso3166 ISO3166, regexps *regexpCache) (valid bool, mobile bool) {
+	if !isLandlineWithCache(number, iso3166, regexps) {
 		valid = false
 		mobile = false
 		return
@@ -200,7 +1077,7 @@ func validatePhoneISO3166(number string, iso3166 ISO3166) (valid bool, mobile bo
 	// Landline check passed, but maybe number is mobile
 	valid = true
 
-	if validateMobileISO3166(number, iso3166) {
+	if isMobileWithCache(number, iso3166, regexps) {
 		// Mobile check passed
 		mobile = true
 	}
@@ -226,17 +1103,69 @@ func indexOfInt(word int, data []int) int {
 	return -1
 }
 
-var rMap = map[string]*regexp.Regexp{}
-var rLock = sync.RWMutex{}
+func maxInt(data []int) int {
+	result := 0
+	for _, v := range data {
+		if v > result {
+			result = v
+		}
+	}
+	return result
+}
 
-func getRegexpByCountryCode(countryCode string) *regexp.Regexp {
-	rLock.Lock()
-	defer rLock.Unlock()
-	regex, exists := rMap[countryCode]
+// regexpCache keeps the regexps used by the validators, they are compiled on first use
+type regexpCache struct {
+	lock sync.RWMutex
+	m    map[string]*regexp.Regexp
+}
+
+func newRegexpCache() *regexpCache {
+	return &regexpCache{m: map[string]*regexp.Regexp{}}
+}
+
+// rCache is the regexp cache of the package-level functions
+var rCache = newRegexpCache()
+
+// WarmCache precompiles the country code regexps used by the validators for every country.
+// Calling it is optional, but recommended for latency-sensitive services,
+// as otherwise the regexps are compiled on first use.
+func WarmCache() {
+	rCache.lock.Lock()
+	defer rCache.lock.Unlock()
+	for _, i := range GetISO3166() {
+		rCache.warm(i.CountryCode)
+	}
+}
+
+// warm compiles the regexp if it is not cached yet, the lock must be held by the caller
+func (c *regexpCache) warm(countryCode string) {
+	if _, exists := c.m[countryCode]; !exists {
+		c.m[countryCode] = regexp.MustCompile(`^` + countryCode)
+	}
+}
+
+func (c *regexpCache) get(countryCode string) *regexp.Regexp {
+	c.lock.RLock()
+	regex, exists := c.m[countryCode]
+	c.lock.RUnlock()
 	if exists {
 		return regex
-	} else {
-		rMap[countryCode] = regexp.MustCompile(`^` + countryCode)
 	}
-	return rMap[countryCode]
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	// Another goroutine may have compiled the regexp while waiting for the lock
+	c.warm(countryCode)
+	return c.m[countryCode]
+}
+
+// reset drops all the compiled regexps
+func (c *regexpCache) reset() {
+	c.lock.Lock()
+	c.m = map[string]*regexp.Regexp{}
+	c.lock.Unlock()
+}
+
+func getRegexpByCountryCode(countryCode string) *regexp.Regexp {
+	return rCache.get(countryCode)
 }
@@ -1,6 +1,13 @@
 package phonenumber
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -129,11 +136,20 @@ var mobWithLLFormatTests = []struct {
 	{"+3726823000", "EE", "3726823000", true, false},
 	{"3726823000", "EE", "3726823000", true, false},
 	{"7499 709 88 33", "RU", "74997098833", true, false},
+	{"8 495 123 45 67", "RU", "74951234567", true, false},
+	{"8 (495) 123-45-67", "RU", "74951234567", true, false},
+	{"+7 8 495 123 45 67", "RU", "74951234567", true, false},
+	{"8 800 555 35 35", "RU", "78005553535", true, false},
+	{"800 555 35 35", "RU", "78005553535", true, false},
+	{"8 727 123 4567", "KZ", "77271234567", true, false},
+	{"8 747 123 4567", "KZ", "77471234567", true, true},
 	{"22 (483) 53-34", "PL", "48224835334", true, false},
 	{"48224835334", "PL", "48224835334", true, false},
 	{"+51 (1) 706-19-70", "PE", "5117061970", true, false},
 	{"+86 21 85-512-329", "CN", "862185512329", true, false},
 	{"+383 9 1234999", "XK", "38391234999", true, false},
+	{"+86 130 1234 567", "CN", "861301234567", true, false},
+	{"01512 34567890", "DE", "49151234567890", true, false},
 
 	// Mobile numbers
 	{"090 6135 3368", "JP", "819061353368", true, true},
@@ -145,7 +161,16 @@ var mobWithLLFormatTests = []struct {
 	{"00371 25 641 580", "LV", "37125641580", true, true},
 	{"+51 999 400 500", "PE", "51999400500", true, true},
 	{"+86 (16) 855-512-329", "CN", "8616855512329", true, true},
+	{"＋８６ １５９ ４８６９ ２３６０", "CN", "8615948692360", true, true},
+	{"०९० ६१३५ ३३६८", "JP", "819061353368", true, true},
+	{"٠٠٣٧١ ٢٥ ٦٤١ ٥٨٠", "LV", "37125641580", true, true},
 	{"+383 4 1234999", "XK", "38341234999", true, true},
+	{"01512 3456789", "DE", "4915123456789", true, true},
+	{"+49 1512 3456789", "DE", "4915123456789", true, true},
+	{"06 20 123 4567", "HU", "36201234567", true, true},
+	{"+36 20 123 4567", "HU", "36201234567", true, true},
+	{"0036 20 123 4567", "HU", "36201234567", true, true},
+	{"+1 1 (817) 569-8900", "US", "18175698900", true, true},
 
 	// Invalid numbers/inputs
 	{"+1 289 2999", "USA", "", false, false},
@@ -189,6 +214,49 @@ func TestFormatWithFlags(t *testing.T) {
 	}
 }
 
+func TestParseInto(t *testing.T) {
+	inputs := []struct {
+		input   string
+		country string
+	}{
+		{"+371 25 641 580 ext. 12", "LV"},
+		{"8 999 123 45 67", "RU"},
+	}
+	for _, tt := range mobWithLLFormatTests {
+		inputs = append(inputs, struct{ input, country string }{tt.input, tt.country})
+	}
+	for _, tt := range leadingZeroTests {
+		inputs = append(inputs, struct{ input, country string }{tt.input, tt.country})
+	}
+
+	dst := make([]byte, 20)
+	for _, tt := range inputs {
+		expected := Parse(tt.input, tt.country)
+		n, valid := ParseInto(dst, tt.input, tt.country)
+		if string(dst[:n]) != expected || valid != (expected != "") {
+			t.Errorf("ParseInto(number=`%s`, country=`%s`): expected (`%s`, %t), actual (`%s`, %t)", tt.input, tt.country, expected, expected != "", dst[:n], valid)
+		}
+	}
+
+	if n, valid := ParseInto(make([]byte, 5), "+371 25 641 580", "LV"); n != 0 || valid {
+		t.Errorf("ParseInto(dst=5 bytes, number=`+371 25 641 580`, country=`LV`): expected (0, false), actual (%d, %t)", n, valid)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for _, tt := range mobWithLLFormatTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			if valid := IsValidLandline(tt.input, tt.country); valid != tt.valid {
+				t.Errorf("IsValidLandline(number=`%s`, country=`%s`): expected `%t`, actual `%t`", tt.input, tt.country, tt.valid, valid)
+			}
+			if mobile := IsValidMobile(tt.input, tt.country); mobile != tt.mobile {
+				t.Errorf("IsValidMobile(number=`%s`, country=`%s`): expected `%t`, actual `%t`", tt.input, tt.country, tt.mobile, mobile)
+			}
+		})
+	}
+}
+
 // Test the real and validated mobile number for India country
 // We added "910" prefix that does not match a specification, but the numbers are really exists
 var indiaMobileTests = []struct {
@@ -382,7 +450,6 @@ var mobileNumbers = []struct {
 }{
 	// Mobile numbers
 	{"39339638066", "IT"},
-	{"07933846223", "GB"},
 	{"14855512329", "CN"},
 }
 
@@ -393,6 +460,9 @@ func TestGetISO3166ByMobileNumber(t *testing.T) {
 			t.Parallel()
 			countries := GetISO3166ByMobileNumber(tt.input)
 			expected := getISO3166ByCountry(tt.expected)
+			if len(countries) == 0 {
+				t.Errorf("GetISO3166ByMobileNumber(number=`%s`): expected `%s`, actual none", tt.input, expected.CountryName)
+			}
 			for _, country := range countries {
 				if country.CountryName != expected.CountryName {
 					t.Errorf("GetISO3166ByMobileNumber(number=`%s`): expected `%s`, actual `%s`", tt.input, expected.CountryName, country.CountryName)
@@ -401,3 +471,1893 @@ func TestGetISO3166ByMobileNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestGetISO3166ByMobileNumberNationalPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		input    string
+		expected string
+	}{
+		{"07933846223", "GB"},
+		{"09061353368", "JP"},
+	} {
+		found := false
+		for _, country := range GetISO3166ByMobileNumber(tt.input) {
+			found = found || country.Alpha2 == tt.expected
+		}
+		if !found {
+			t.Errorf("GetISO3166ByMobileNumber(number=`%s`): expected `%s` among the countries", tt.input, tt.expected)
+		}
+	}
+}
+
+func TestGetISO3166ByMobileNumberRanking(t *testing.T) {
+	countries := GetISO3166ByMobileNumber("9161234567")
+	if len(countries) < 2 || countries[0].Alpha2 != "US" || countries[len(countries)-1].Alpha2 == "US" {
+		t.Fatalf("GetISO3166ByMobileNumber(number=`9161234567`): expected US with the area code prefix first, actual `%v`", countries)
+	}
+
+	seen := map[string]bool{}
+	found := false
+	for _, country := range countries {
+		if seen[country.Alpha3] {
+			t.Errorf("GetISO3166ByMobileNumber(number=`9161234567`): `%s` is returned more than once", country.Alpha3)
+		}
+		seen[country.Alpha3] = true
+		found = found || country.Alpha2 == "RU"
+	}
+	if !found {
+		t.Errorf("GetISO3166ByMobileNumber(number=`9161234567`): expected RU among the countries")
+	}
+}
+
+// Detailed parse results
+var detailedTests = []struct {
+	input          string
+	country        string
+	expected       string
+	alpha2         string
+	nationalNumber string
+	matchedLength  int
+	valid          bool
+	mobile         bool
+}{
+	{"+371 (67) 881-727", "LV", "37167881727", "LV", "67881727", 8, true, false},
+	{"090 6135 3368", "JP", "819061353368", "JP", "9061353368", 10, true, true},
+	{"+86 21 85-512-329", "CN", "862185512329", "CN", "2185512329", 10, true, false},
+	{"+86 (16) 855-512-329", "CN", "8616855512329", "CN", "16855512329", 11, true, true},
+	{"(817) 569-8900", "USA", "18175698900", "US", "8175698900", 10, true, true},
+	{"+1 289 2999", "USA", "", "US", "2892999", 0, false, false},
+	{"8615948692360", "JP", "", "JP", "8615948692360", 0, false, false},
+	{"38341234999", "XXXK", "", "", "38341234999", 0, false, false},
+}
+
+// Length of the national significant number
+var nationalNumberLengthTests = []struct {
+	input    string
+	country  string
+	expected int
+}{
+	{"+371 25 641 580", "LV", 8},
+	{"25 641 580", "LV", 8},
+	{"2564158", "LV", 7},
+	{"+1 (817) 569-8900", "US", 10},
+	{"8 (999) 123-45-67", "RU", 10},
+	{"37", "LV", 0},
+	{"", "LV", 0},
+	{"25 641 580", "XX", 0},
+}
+
+func TestNationalNumberLength(t *testing.T) {
+	for _, tt := range nationalNumberLengthTests {
+		if length := NationalNumberLength(tt.input, tt.country); length != tt.expected {
+			t.Errorf("NationalNumberLength(number=`%s`, country=`%s`): expected `%d`, actual `%d`", tt.input, tt.country, tt.expected, length)
+		}
+	}
+}
+
+// National significant numbers of the valid mobile numbers
+var parseNationalTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"+371 25 641 580", "LV", "25641580"},
+	{"25 641 580", "LV", "25641580"},
+	{"+1 (817) 569-8900", "US", "8175698900"},
+	{"8 (999) 123-45-67", "RU", "9991234567"},
+	{"+225 07 77 40 11 60", "CI", "0777401160"},
+	{"+44 7700 900000", "GB", "7700900000"},
+	{"+371 (67) 881-727", "LV", ""},
+	{"25 641 580", "XX", ""},
+	{"", "LV", ""},
+}
+
+func TestParseNational(t *testing.T) {
+	for _, tt := range parseNationalTests {
+		if number := ParseNational(tt.input, tt.country); number != tt.expected {
+			t.Errorf("ParseNational(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+		}
+	}
+}
+
+// Parse too long numbers, dropping the trailing digits
+var bestEffortTests = []struct {
+	input     string
+	country   string
+	expected  string
+	truncated bool
+}{
+	{"+371 25 641 580", "LV", "37125641580", false},
+	{"+371 25 641 580 9", "LV", "37125641580", true},
+	{"25641580 25641580", "LV", "37125641580", true},
+	{"+1 (817) 569-8900 8900", "US", "18175698900", true},
+	{"8 916 123-45-67 45", "RU", "79161234567", true},
+	{"+371 25 641 580 ext. 12", "LV", "37125641580", false},
+	{"2564158", "LV", "", false},
+	{"67881727 9", "LV", "", false},
+	{"25641580 9", "XX", "", false},
+	{"", "LV", "", false},
+}
+
+func TestParseBestEffort(t *testing.T) {
+	for _, tt := range bestEffortTests {
+		if number, truncated := ParseBestEffort(tt.input, tt.country); number != tt.expected || truncated != tt.truncated {
+			t.Errorf("ParseBestEffort(number=`%s`, country=`%s`): expected (`%s`, %t), actual (`%s`, %t)", tt.input, tt.country, tt.expected, tt.truncated, number, truncated)
+		}
+	}
+}
+
+func TestParseMobileOrLandline(t *testing.T) {
+	for _, tt := range mobWithLLFormatTests {
+		if parsed, mobile := ParseMobileOrLandline(tt.input, tt.country); parsed != tt.expected || mobile != tt.mobile {
+			t.Errorf("ParseMobileOrLandline(number=`%s`, country=`%s`): expected (`%s`, %t), actual (`%s`, %t)", tt.input, tt.country, tt.expected, tt.mobile, parsed, mobile)
+		}
+	}
+}
+
+func TestParseSplit(t *testing.T) {
+	for _, tt := range mobWithLLFormatTests {
+		countryCode, national, valid, mobile := ParseSplit(tt.input, tt.country)
+		if countryCode+national != tt.expected || valid != tt.valid || mobile != tt.mobile {
+			t.Errorf("ParseSplit(number=`%s`, country=`%s`): expected (`%s`, %t, %t), actual (`%s`, `%s`, %t, %t)", tt.input, tt.country, tt.expected, tt.valid, tt.mobile, countryCode, national, valid, mobile)
+		}
+	}
+
+	if countryCode, national, _, _ := ParseSplit("+1 (204) 555-0143", "CA"); countryCode != "1" || national != "2045550143" {
+		t.Errorf("ParseSplit(number=`+1 (204) 555-0143`, country=`CA`): expected (`1`, `2045550143`), actual (`%s`, `%s`)", countryCode, national)
+	}
+	if countryCode, national, _, _ := ParseSplit("+371 25 641 580", "LV"); countryCode != "371" || national != "25641580" {
+		t.Errorf("ParseSplit(number=`+371 25 641 580`, country=`LV`): expected (`371`, `25641580`), actual (`%s`, `%s`)", countryCode, national)
+	}
+}
+
+func TestInfo(t *testing.T) {
+	info := Info("+1 (202) 555-0143 ext. 12", "US")
+	expected := NumberInfo{
+		E164:          "12025550143",
+		National:      "(202) 555-0143",
+		International: "+1 202-555-0143",
+		Valid:         true,
+		Type:          Mobile,
+		Extension:     "12",
+	}
+	if info.Country.Alpha2 != "US" {
+		t.Errorf("Info(number=`+1 (202) 555-0143 ext. 12`, country=`US`): expected country `US`, actual `%s`", info.Country.Alpha2)
+	}
+	if info.Country = (ISO3166{}); !reflect.DeepEqual(info, expected) {
+		t.Errorf("Info(number=`+1 (202) 555-0143 ext. 12`, country=`US`): expected `%+v`, actual `%+v`", expected, info)
+	}
+
+	if info := Info("+371 (67) 881-727", "LV"); info.Type != FixedLine || info.International != "+371 67 881 727" {
+		t.Errorf("Info(number=`+371 (67) 881-727`, country=`LV`): expected fixed line `+371 67 881 727`, actual %d `%s`", info.Type, info.International)
+	}
+	if info := Info("2564158 x12", "LV"); info.Valid || info.E164 != "" || info.Extension != "" || info.Country.Alpha2 != "LV" {
+		t.Errorf("Info(number=`2564158 x12`, country=`LV`): expected invalid number of LV, actual `%s` `%s` %t", info.E164, info.Extension, info.Valid)
+	}
+}
+
+// parseCounter is an Observer counting the parsed numbers by country and outcome
+type parseCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *parseCounter) OnParse(country string, valid bool, mobile bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[fmt.Sprintf("%s/%t/%t", country, valid, mobile)]++
+}
+
+func TestParseOrDefault(t *testing.T) {
+	if number := ParseOrDefault("+371 25 641 580", "LV", "n/a"); number != "37125641580" {
+		t.Errorf("ParseOrDefault(number=`+371 25 641 580`, country=`LV`): expected `37125641580`, actual `%s`", number)
+	}
+	if number := ParseOrDefault("+371 (67) 881-727", "LV", "n/a"); number != "n/a" {
+		t.Errorf("ParseOrDefault(number=`+371 (67) 881-727`, country=`LV`): expected `n/a`, actual `%s`", number)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if number := MustParse("+371 25 641 580", "LV"); number != "37125641580" {
+		t.Errorf("MustParse(number=`+371 25 641 580`, country=`LV`): expected `37125641580`, actual `%s`", number)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustParse(number=`256`, country=`LV`): expected a panic")
+		}
+	}()
+	MustParse("256", "LV")
+}
+
+func TestSetDefaultCountry(t *testing.T) {
+	if number := Parse("25 641 580", ""); number != "" {
+		t.Errorf("Parse(number=`25 641 580`, country=``): expected `` without default country, actual `%s`", number)
+	}
+
+	SetDefaultCountry("LV")
+	defer SetDefaultCountry("")
+	if number := Parse("25 641 580", ""); number != "37125641580" {
+		t.Errorf("Parse(number=`25 641 580`, country=``): expected `37125641580`, actual `%s`", number)
+	}
+	if number := Parse("+44 7700 900000", "GB"); number != "447700900000" {
+		t.Errorf("Parse(number=`+44 7700 900000`, country=`GB`): expected `447700900000`, actual `%s`", number)
+	}
+	if number := ParseWithHint("+44 7700 900000", ""); number != "447700900000" {
+		t.Errorf("ParseWithHint(number=`+44 7700 900000`, country=``): expected `447700900000`, actual `%s`", number)
+	}
+	if number := ParseTagged("25 641 580"); number != "" {
+		t.Errorf("ParseTagged(s=`25 641 580`): expected `` without the tag, actual `%s`", number)
+	}
+
+	SetDefaultCountry("")
+	if number := Parse("25 641 580", ""); number != "" {
+		t.Errorf("Parse(number=`25 641 580`, country=``): expected `` after the default country is removed, actual `%s`", number)
+	}
+}
+
+func TestSetObserver(t *testing.T) {
+	counter := &parseCounter{counts: map[string]int{}}
+	SetObserver(counter)
+	defer SetObserver(nil)
+
+	Parse("+371 25 641 580", "LV")
+	ParseWithLandLine("+371 (67) 881-727", "LV")
+	ParseWithFlags("+371 (67) 881-727", "LV")
+	ParseBatch([]string{"25641580", "256"}, "LV")
+	Parse("25641580", "XX")
+
+	expected := map[string]int{"LV/true/true": 2, "LV/true/false": 2, "LV/false/false": 1, "/false/false": 1}
+	if !reflect.DeepEqual(counter.counts, expected) {
+		t.Errorf("SetObserver(): expected counts `%v`, actual `%v`", expected, counter.counts)
+	}
+
+	SetObserver(nil)
+	Parse("+371 25 641 580", "LV")
+	if counter.counts["LV/true/true"] != 2 {
+		t.Errorf("SetObserver(nil): expected no more calls, actual `%v`", counter.counts)
+	}
+}
+
+func TestParseDetailed(t *testing.T) {
+	for _, tt := range detailedTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			result := ParseDetailed(tt.input, tt.country)
+			if result.E164 != tt.expected {
+				t.Errorf("ParseDetailed(number=`%s`, country=`%s`): expected E164 `%s`, actual `%s`", tt.input, tt.country, tt.expected, result.E164)
+			}
+			if result.RawInput != tt.input {
+				t.Errorf("ParseDetailed(number=`%s`, country=`%s`): expected raw input `%s`, actual `%s`", tt.input, tt.country, tt.input, result.RawInput)
+			}
+			if result.ISO3166.Alpha2 != tt.alpha2 {
+				t.Errorf("ParseDetailed(number=`%s`, country=`%s`): expected country `%s`, actual `%s`", tt.input, tt.country, tt.alpha2, result.ISO3166.Alpha2)
+			}
+			if result.NationalNumber != tt.nationalNumber {
+				t.Errorf("ParseDetailed(number=`%s`, country=`%s`): expected national number `%s`, actual `%s`", tt.input, tt.country, tt.nationalNumber, result.NationalNumber)
+			}
+			if result.MatchedLength != tt.matchedLength {
+				t.Errorf("ParseDetailed(number=`%s`, country=`%s`): expected matched length `%d`, actual `%d`", tt.input, tt.country, tt.matchedLength, result.MatchedLength)
+			}
+			if length, valid := MatchedLength(tt.input, tt.country); length != tt.matchedLength || valid != tt.valid {
+				t.Errorf("MatchedLength(number=`%s`, country=`%s`): expected (%d, %t), actual (%d, %t)", tt.input, tt.country, tt.matchedLength, tt.valid, length, valid)
+			}
+			if result.Valid != tt.valid {
+				t.Errorf("ParseDetailed(number=`%s`, country=`%s`): expected valid `%t`, actual `%t`", tt.input, tt.country, tt.valid, result.Valid)
+			}
+			if result.Mobile != tt.mobile {
+				t.Errorf("ParseDetailed(number=`%s`, country=`%s`): expected mobile `%t`, actual `%t`", tt.input, tt.country, tt.mobile, result.Mobile)
+			}
+		})
+	}
+}
+
+// Parse with error reasons
+var e164Tests = []struct {
+	input    string
+	country  string
+	expected string
+	err      error
+}{
+	{"090 6135 3368", "JP", "819061353368", nil},
+	{"+371 25 641 580", "LV", "37125641580", nil},
+	{"+371 (67) 881-727", "LV", "", ErrNotMobile},
+	{"+1 289 2999", "USA", "", ErrInvalidLength},
+	{"8615948692360", "JP", "", ErrInvalidLength},
+	{"38341234999", "XXXK", "", ErrUnknownCountry},
+	{"+38341234999", "", "", ErrUnknownCountry},
+}
+
+func TestParseE164(t *testing.T) {
+	for _, tt := range e164Tests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			number, err := ParseE164(tt.input, tt.country)
+			if number != tt.expected {
+				t.Errorf("ParseE164(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("ParseE164(number=`%s`, country=`%s`): expected error `%v`, actual `%v`", tt.input, tt.country, tt.err, err)
+			}
+		})
+	}
+}
+
+// Numbers with extensions
+var extensionTests = []struct {
+	input     string
+	country   string
+	expected  string
+	extension string
+}{
+	{"+1 202-555-0143 ext. 123", "US", "12025550143", "123"},
+	{"+1 202-555-0143 EXT 123", "US", "12025550143", "123"},
+	{"(202) 555-0143 extension 45", "US", "12025550143", "45"},
+	{"202-555-0143x123", "US", "12025550143", "123"},
+	{"202-555-0143 #9", "US", "12025550143", "9"},
+	{"8 916 123-45-67 доб. 12", "RU", "79161234567", "12"},
+	{"8 916 123-45-67 поб 12", "RU", "79161234567", "12"},
+	{"+1 202-555-0143", "US", "12025550143", ""},
+	{"+1 289 2999 ext. 12", "US", "", ""},
+}
+
+func TestParseWithExtension(t *testing.T) {
+	for _, tt := range extensionTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			number, ext := ParseWithExtension(tt.input, tt.country)
+			if number != tt.expected {
+				t.Errorf("ParseWithExtension(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+			}
+			if ext != tt.extension {
+				t.Errorf("ParseWithExtension(number=`%s`, country=`%s`): expected extension `%s`, actual `%s`", tt.input, tt.country, tt.extension, ext)
+			}
+			if number != "" && Parse(tt.input, tt.country) != number {
+				t.Errorf("Parse(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, number, Parse(tt.input, tt.country))
+			}
+		})
+	}
+}
+
+func TestWarmCache(t *testing.T) {
+	rCache.reset()
+	WarmCache()
+
+	codes := map[string]bool{}
+	for _, i := range GetISO3166() {
+		codes[i.CountryCode] = true
+	}
+	rCache.lock.RLock()
+	defer rCache.lock.RUnlock()
+	for code := range codes {
+		if _, exists := rCache.m[code]; !exists {
+			t.Errorf("WarmCache(): regexp for the country code `%s` is not compiled", code)
+		}
+	}
+	for code := range rCache.m {
+		if !codes[code] {
+			t.Errorf("WarmCache(): regexp for `%s` is compiled, but it is not a country code", code)
+		}
+	}
+}
+
+// TestParseConcurrent is meant to be run with -race, the regexp cache and
+// the country registry are shared by all the goroutines
+func TestParseConcurrent(t *testing.T) {
+	expected := make([]string, len(mobWithLLFormatTests))
+	for k, tt := range mobWithLLFormatTests {
+		expected[k] = Parse(tt.input, tt.country)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 16; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for k := range mobWithLLFormatTests {
+				// Every goroutine starts at another country, so the cache misses overlap
+				tt := mobWithLLFormatTests[(k+g)%len(mobWithLLFormatTests)]
+				if number := Parse(tt.input, tt.country); number != expected[(k+g)%len(expected)] {
+					t.Errorf("Parse(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, expected[(k+g)%len(expected)], number)
+				}
+				GetISO3166ByNumber(expected[(k+g)%len(expected)], true)
+			}
+		}(g)
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for k := 0; k < 4; k++ {
+			WarmCache()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		lv := getISO3166ByCountry("LV")
+		for k := 0; k < 4; k++ {
+			if err := OverrideCountry("LV", lv); err != nil {
+				t.Errorf("OverrideCountry(LV): unexpected error `%v`", err)
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+// Number types
+var numberTypeTests = []struct {
+	input    string
+	country  string
+	expected NumberType
+}{
+	{"+1 800 555 0143", "US", TollFree},
+	{"(888) 555-0143", "US", TollFree},
+	{"+1 900 555 0143", "US", PremiumRate},
+	{"(817) 569-8900", "US", Mobile},
+	{"+371 (67) 881-727", "LV", FixedLine},
+	{"+371 25 641 580", "LV", Mobile},
+	{"0800 123 4567", "GB", TollFree},
+	{"0845 123 4567", "GB", SharedCost},
+	{"0909 123 4567", "GB", PremiumRate},
+	{"0560 123 4567", "GB", VOIP},
+	{"020 7946 0000", "GB", FixedLine},
+	{"0800 1234567", "DE", TollFree},
+	{"+7 800 555 35 35", "RU", TollFree},
+	{"+1 289 2999", "US", Unknown},
+	{"38341234999", "XXXK", Unknown},
+}
+
+// Validate numbers against the expected type
+var validateTypeTests = []struct {
+	input    string
+	country  string
+	want     NumberType
+	expected bool
+}{
+	{"+371 25 641 580", "LV", Mobile, true},
+	{"+371 25 641 580", "LV", FixedLine, false},
+	{"+371 67 881 727", "LV", FixedLine, true},
+	{"+371 67 881 727", "LV", Mobile, false},
+	{"+1 202 555 0143", "US", Mobile, true},
+	{"+1 202 555 0143", "US", FixedLine, true},
+	{"+52 55 1234 5678", "MX", Mobile, true},
+	{"+52 55 1234 5678", "MX", FixedLine, true},
+	{"+1 800 555 0143", "US", TollFree, true},
+	{"+1 800 555 0143", "US", FixedLine, false},
+	{"+371 256", "LV", Mobile, false},
+	{"+371 256", "LV", Unknown, false},
+}
+
+func TestValidateType(t *testing.T) {
+	for _, tt := range validateTypeTests {
+		if valid := ValidateType(tt.input, tt.country, tt.want); valid != tt.expected {
+			t.Errorf("ValidateType(number=`%s`, country=`%s`, want=`%d`): expected `%t`, actual `%t`", tt.input, tt.country, tt.want, tt.expected, valid)
+		}
+	}
+}
+
+func TestGetNumberType(t *testing.T) {
+	for _, tt := range numberTypeTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			numberType := GetNumberType(tt.input, tt.country)
+			if numberType != tt.expected {
+				t.Errorf("GetNumberType(number=`%s`, country=`%s`): expected `%d`, actual `%d`", tt.input, tt.country, tt.expected, numberType)
+			}
+		})
+	}
+}
+
+// Parse with the country from the number itself
+var hintTests = []struct {
+	input    string
+	hint     string
+	expected string
+}{
+	{"+44 7700 900000", "US", "447700900000"},
+	{"+44 (0) 7700 900000", "US", "447700900000"},
+	{"+ 44 7700 900000", "US", "447700900000"},
+	{"++44 7700 900000", "US", "447700900000"},
+	{"+ + 44 7700 900000", "US", "447700900000"},
+	{"\t+44 7700 900000", "US", "447700900000"},
+	{"+ 1 (202) 555-0143", "GB", "12025550143"},
+	{"++1 (204) 555-0143", "GB", "12045550143"},
+	{"+44", "US", ""},
+	{"++", "US", ""},
+	{"+1 (204) 555-0143", "GB", "12045550143"},
+	{"+371 25 641 580", "", "37125641580"},
+	{"07700 900000", "GB", "447700900000"},
+	{"(817) 569-8900", "US", "18175698900"},
+	{"07700 900000", "LV", ""},
+	{"+44 20 7946 0000", "US", ""},
+	{"+999 1234 5678", "US", ""},
+}
+
+func TestParseWithHint(t *testing.T) {
+	for _, tt := range hintTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			number := ParseWithHint(tt.input, tt.hint)
+			if number != tt.expected {
+				t.Errorf("ParseWithHint(number=`%s`, hint=`%s`): expected `%s`, actual `%s`", tt.input, tt.hint, tt.expected, number)
+			}
+		})
+	}
+}
+
+// Parse numbers prefixed with their country
+var taggedTests = []struct {
+	input    string
+	expected string
+}{
+	{"United States +1 202 555 0143", "12025550143"},
+	{"United States (202) 555-0143", "12025550143"},
+	{"UK: 07700 900000", "447700900000"},
+	{"GBR - +44 7700 900000", "447700900000"},
+	{"lv 25 641 580", "37125641580"},
+	{"Latvia: +371 25 641 580", "37125641580"},
+	{"+371 25 641 580", "37125641580"},
+	{"  +371 25 641 580", "37125641580"},
+	{"25 641 580", ""},
+	{"Tel: +371 25 641 580", ""},
+	{"Atlantis 25 641 580", ""},
+	{"United States", ""},
+	{"", ""},
+}
+
+func TestParseTagged(t *testing.T) {
+	for _, tt := range taggedTests {
+		if number := ParseTagged(tt.input); number != tt.expected {
+			t.Errorf("ParseTagged(s=`%s`): expected `%s`, actual `%s`", tt.input, tt.expected, number)
+		}
+	}
+}
+
+// Parse numbers, rejecting the numbers with the country code of another country
+var strictTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"+371 25 641 580", "LV", "37125641580"},
+	{"25 641 580", "LV", "37125641580"},
+	{"+1 (817) 569-8900", "US", "18175698900"},
+	{"+358 18 493 71", "IT", ""},
+	{"+1 684 258 7005", "CN", ""},
+	{"+1 970 791 8506", "MX", ""},
+	{"+44 7700 900000", "US", ""},
+}
+
+func TestParseStrict(t *testing.T) {
+	for _, tt := range strictTests {
+		if number := ParseStrict(tt.input, tt.country); number != tt.expected {
+			t.Errorf("ParseStrict(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+		}
+	}
+}
+
+// Reasons why the numbers are invalid
+var diagnoseTests = []struct {
+	input    string
+	country  string
+	expected []string
+}{
+	{"+371 25 641 580", "LV", []string{}},
+	{"+371 (67) 881-727", "LV", []string{"prefix 6 not in mobile prefixes"}},
+	{"2564158", "LV", []string{"length 7 not in [8]"}},
+	{"0512 345", "MX", []string{"length 6 not in [10,11]"}},
+	{"+1 289 2999", "USA", []string{"length 7 not in [10]", "prefix 289 not in mobile prefixes"}},
+	{"123456", "US", []string{"length 6 not in [10]", "prefix 123 not in mobile prefixes"}},
+	{"1", "US", []string{"length 1 not in [10]", "prefix 1 not in mobile prefixes"}},
+	{"+1", "US", []string{"length 0 not in [10]"}},
+	{"+86 21 1234 567", "CN", []string{"length 9 not in [11]", "prefix 21 not in mobile prefixes"}},
+	{"0086 21 1234 567", "CN", []string{"length 9 not in [11]", "prefix 21 not in mobile prefixes"}},
+	{"abc", "LV", []string{"no digits in the number"}},
+	{"123", "XX", []string{"unknown country 'XX'"}},
+}
+
+func TestDiagnose(t *testing.T) {
+	for _, tt := range diagnoseTests {
+		if reasons := Diagnose(tt.input, tt.country); strings.Join(reasons, ";") != strings.Join(tt.expected, ";") {
+			t.Errorf("Diagnose(number=`%s`, country=`%s`): expected `%q`, actual `%q`", tt.input, tt.country, tt.expected, reasons)
+		}
+	}
+
+	// There are no reasons exactly for the valid mobile numbers
+	for _, tt := range mobWithLLFormatTests {
+		if reasons := Diagnose(tt.input, tt.country); (len(reasons) == 0) != tt.mobile {
+			t.Errorf("Diagnose(number=`%s`, country=`%s`): expected mobile `%t`, actual reasons `%q`", tt.input, tt.country, tt.mobile, reasons)
+		}
+	}
+}
+
+// Strict E.164 numbers
+var isE164Tests = []struct {
+	input    string
+	expected bool
+}{
+	{"+12025550143", true},
+	{"+37125641580", true},
+	{"+3", false},
+	{"+7", true},
+	{"+123456789012345", true},
+	{"+1234567890123456", false},
+	{"12025550143", false},
+	{"+1 202 555 0143", false},
+	{"+1-202-555-0143", false},
+	{"+02025550143", false},
+	{"+", false},
+	{"", false},
+	{"++12025550143", false},
+	{"+１２０２５５５０１４３", false},
+}
+
+func TestIsE164(t *testing.T) {
+	for _, tt := range isE164Tests {
+		if valid := IsE164(tt.input); valid != tt.expected {
+			t.Errorf("IsE164(number=`%s`): expected `%t`, actual `%t`", tt.input, tt.expected, valid)
+		}
+	}
+}
+
+// Parse numbers and countries with any whitespace
+var whitespaceTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"\u00a0+371\u00a025\u00a0641\u00a0580", "LV", "37125641580"},
+	{"\t+371 25 641 580\n", "LV", "37125641580"},
+	{"+371\u202f25\u2009641\u3000580", "LV", "37125641580"},
+	{"+371 25 641 580", "\tLV ", "37125641580"},
+	{"+371 25 641 580", "\u00a0lva\r\n", "37125641580"},
+	{"(817) 569-8900", " US\t", "18175698900"},
+}
+
+func TestParseWhitespace(t *testing.T) {
+	for _, tt := range whitespaceTests {
+		if number := Parse(tt.input, tt.country); number != tt.expected {
+			t.Errorf("Parse(number=`%q`, country=`%q`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+		}
+	}
+	if number := ParseWithHint("\u00a0+44 7700 900000", "US"); number != "447700900000" {
+		t.Errorf("ParseWithHint(number=`\\u00a0+44 7700 900000`, hint=`US`): expected `447700900000`, actual `%s`", number)
+	}
+}
+
+// Parse numbers with the country code digits inside the number
+var embeddedCountryCodeTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"2537 1058", "LV", "37125371058"},
+	{"+371 2537 1058", "LV", "37125371058"},
+	{"(817) 111-1011", "US", "18171111011"},
+	{"+1 (817) 111-1011", "US", "18171111011"},
+	{"8 916 777 70 77", "RU", "79167777077"},
+	{"+7 916 707 70 77", "RU", "79167077077"},
+	{"090 8181 8181", "JP", "819081818181"},
+}
+
+func TestParseEmbeddedCountryCode(t *testing.T) {
+	for _, tt := range embeddedCountryCodeTests {
+		if number := Parse(tt.input, tt.country); number != tt.expected {
+			t.Errorf("Parse(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+		}
+	}
+}
+
+// Parse numbers with options
+var optionsTests = []struct {
+	input     string
+	opts      Options
+	expected  string
+	extension string
+}{
+	{"+371 25 641 580", Options{DefaultCountry: "LV"}, "37125641580", ""},
+	{"+371 (67) 881-727", Options{DefaultCountry: "LV"}, "", ""},
+	{"+371 (67) 881-727", Options{DefaultCountry: "LV", AllowLandline: true}, "37167881727", ""},
+	{"+371 25 641 580 ext. 12", Options{DefaultCountry: "LV"}, "37125641580", ""},
+	{"+371 25 641 580 ext. 12", Options{DefaultCountry: "LV", KeepExtension: true}, "37125641580", "12"},
+	{"+358 18 493 71", Options{DefaultCountry: "IT", Strict: true}, "", ""},
+	{"+39 312 345 6789", Options{DefaultCountry: "IT", Strict: true}, "393123456789", ""},
+	{"+371 25 641 580", Options{}, "", ""},
+	{"+371 22 222 222", Options{DefaultCountry: "LV"}, "37122222222", ""},
+	{"+371 22 222 222", Options{DefaultCountry: "LV", RejectFake: true}, "", ""},
+	{"+371 25 641 580", Options{DefaultCountry: "LV", RejectFake: true}, "37125641580", ""},
+}
+
+func TestParseWithOptions(t *testing.T) {
+	for _, tt := range optionsTests {
+		result := ParseWithOptions(tt.input, tt.opts)
+		if result.E164 != tt.expected || result.Extension != tt.extension {
+			t.Errorf("ParseWithOptions(number=`%s`, opts=%+v): expected (`%s`, `%s`), actual (`%s`, `%s`)", tt.input, tt.opts, tt.expected, tt.extension, result.E164, result.Extension)
+		}
+	}
+
+	// The options with the country only parse like Parse
+	for _, tt := range mobWithLLFormatTests {
+		if result := ParseWithOptions(tt.input, Options{DefaultCountry: tt.country}); result.E164 != Parse(tt.input, tt.country) {
+			t.Errorf("ParseWithOptions(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, Parse(tt.input, tt.country), result.E164)
+		}
+	}
+}
+
+// Obvious placeholder numbers
+var likelyFakeTests = []struct {
+	input    string
+	country  string
+	expected bool
+}{
+	{"+10000000000", "", true},
+	{"1111111111", "US", true},
+	{"(555) 555-5555", "US", true},
+	{"+1 555 555 5555", "US", true},
+	{"123-456-7890", "US", true},
+	{"+371 22 222 222", "LV", true},
+	{"23456789", "LV", true},
+	{"98765432", "LV", true},
+	{"+1 202 555 0143", "US", false},
+	{"+371 25 641 580", "LV", false},
+	{"+44 7700 900000", "GB", false},
+	{"11111", "US", false},
+	{"", "US", false},
+}
+
+func TestIsLikelyFake(t *testing.T) {
+	for _, tt := range likelyFakeTests {
+		if fake := IsLikelyFake(tt.input, tt.country); fake != tt.expected {
+			t.Errorf("IsLikelyFake(number=`%s`, country=`%s`): expected `%t`, actual `%t`", tt.input, tt.country, tt.expected, fake)
+		}
+	}
+}
+
+// Parse vanity numbers with letters
+var vanityTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"1-800-FLOWERS", "US", "18003569377"},
+	{"1-800-go-fedex", "US", "18004633339"},
+	{"+1 (800) FLOWERS ext. 12", "US", "18003569377"},
+	{"1-800-FLOWERS #12", "US", "18003569377"},
+	{"1-800-555-FAX9", "US", "18005553299"},
+	{"(817) 569-8900", "US", "18175698900"},
+	{"1-800-FLOW", "US", ""},
+	{"1-800-FLOWERS", "XX", ""},
+}
+
+func TestParseVanity(t *testing.T) {
+	for _, tt := range vanityTests {
+		if number := ParseVanity(tt.input, tt.country); number != tt.expected {
+			t.Errorf("ParseVanity(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+		}
+	}
+}
+
+// Parse numbers dialed with the international exit code
+var iddTests = []struct {
+	input    string
+	from     string
+	expected string
+}{
+	{"011 44 7700 900000", "US", "447700900000"},
+	{"011 371 25 641 580", "JM", "37125641580"},
+	{"00 44 7700 900000", "LV", "447700900000"},
+	{"0011 44 7700 900000", "AU", "447700900000"},
+	{"810 371 25 641 580", "RU", "37125641580"},
+	{"00 371 25 641 580", "RU", "37125641580"},
+	{"+44 7700 900000", "US", "447700900000"},
+	{"(817) 569-8900", "US", "18175698900"},
+	{"25 641 580", "LV", "37125641580"},
+	{"011 44 20 7946 0000", "US", ""},
+	{"00 44 7700 900000", "XX", ""},
+}
+
+func TestParseWithIDD(t *testing.T) {
+	for _, tt := range iddTests {
+		if number := ParseWithIDD(tt.input, tt.from); number != tt.expected {
+			t.Errorf("ParseWithIDD(number=`%s`, from=`%s`): expected `%s`, actual `%s`", tt.input, tt.from, tt.expected, number)
+		}
+	}
+}
+
+var dialableFromTests = []struct {
+	input    string
+	from     string
+	expected string
+}{
+	{"+447700900000", "US", "011447700900000"},
+	{"+447700900000", "LV", "00447700900000"},
+	{"+447700900000", "AU", "0011447700900000"},
+	{"+447700900000", "GB", "07700900000"},
+	{"+442079460000", "GB", "02079460000"},
+	{"+12025550143", "US", "12025550143"},
+	{"+12025550143", "CA", "12025550143"},
+	{"+37125641580", "RU", "81037125641580"},
+	{"+74951234567", "RU", "84951234567"},
+	{"+390669821234", "IT", "0669821234"},
+	{"+447700900000", "XX", ""},
+	{"+44770090", "US", ""},
+	{"", "US", ""},
+}
+
+func TestDialableFrom(t *testing.T) {
+	for _, tt := range dialableFromTests {
+		if number := DialableFrom(tt.input, tt.from); number != tt.expected {
+			t.Errorf("DialableFrom(number=`%s`, from=`%s`): expected `%s`, actual `%s`", tt.input, tt.from, tt.expected, number)
+		}
+	}
+}
+
+// Normalize numbers without country logic
+var normalizeTests = []struct {
+	input    string
+	expected string
+}{
+	{"+1 (202) 555-0143", "12025550143"},
+	{"090-6135-4467", "09061354467"},
+	{"00371 (67) 881-727", "0037167881727"},
+	{"+44 (0) 20 7946 0000", "4402079460000"},
+	{"abc", ""},
+	{"", ""},
+	{"+371 ٢٥ ٦٤١ ٥٨٠", "37125641580"},
+	{"+１ (２０２) ５５５-０１４３", "12025550143"},
+	{"+१ २०२ ५५५ ०१४३", "12025550143"},
+	{"+𝟏 𝟐𝟎𝟐 𝟓𝟓𝟓 𝟎𝟏𝟒𝟑", "12025550143"},
+	{"½ ² ⑤", ""},
+}
+
+func TestNormalize(t *testing.T) {
+	for _, tt := range normalizeTests {
+		if number := Normalize(tt.input); number != tt.expected {
+			t.Errorf("Normalize(number=`%s`): expected `%s`, actual `%s`", tt.input, tt.expected, number)
+		}
+	}
+}
+
+func TestGetISO3166Copy(t *testing.T) {
+	if len(GetISO3166()) == 0 {
+		t.Fatal("GetISO3166(): must not be empty")
+	}
+	if &GetISO3166()[0] != &GetISO3166()[0] {
+		t.Error("GetISO3166(): must return the shared slice")
+	}
+
+	countries := GetISO3166Copy()
+	if len(countries) != len(GetISO3166()) {
+		t.Fatalf("GetISO3166Copy(): expected %d countries, actual %d", len(GetISO3166()), len(countries))
+	}
+	countries[0].CountryName = "Changed"
+	countries[0].MobileBeginWith[0] = "000"
+	if GetISO3166()[0].CountryName == "Changed" || GetISO3166()[0].MobileBeginWith[0] == "000" {
+		t.Error("GetISO3166Copy(): changing the copy must not change the shared data")
+	}
+}
+
+func TestISO3166JSON(t *testing.T) {
+	iso3166 := getISO3166ByCountry("UA")
+	data, err := json.Marshal(iso3166)
+	if err != nil {
+		t.Fatalf("json.Marshal(UA): unexpected error `%v`", err)
+	}
+	for _, field := range []string{`"alpha2":"UA"`, `"alpha3":"UKR"`, `"country_code":"380"`, `"phone_number_lengths":[9]`, `"mobile_begin_with":[`, `"carriers":{`} {
+		if !strings.Contains(string(data), field) {
+			t.Errorf("json.Marshal(UA): expected `%s` in `%s`", field, data)
+		}
+	}
+
+	decoded := ISO3166{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(UA): unexpected error `%v`", err)
+	}
+	if !reflect.DeepEqual(decoded, iso3166) {
+		t.Errorf("json.Unmarshal(UA): expected `%+v`, actual `%+v`", iso3166, decoded)
+	}
+}
+
+func TestLoadISO3166FromJSON(t *testing.T) {
+	keepISO3166(t)
+
+	input := `[
+		{"alpha2": "ZX", "alpha3": "ZXX", "country_code": "998", "country_name": "Loaded Country", "mobile_begin_with": ["7"], "phone_number_lengths": [8]},
+		{"alpha2": "LV", "alpha3": "LVA", "country_code": "371", "country_name": "Latvia", "mobile_begin_with": ["2", "6"], "phone_number_lengths": [8]}
+	]`
+	if err := LoadISO3166FromJSON(strings.NewReader(input)); err != nil {
+		t.Fatalf("LoadISO3166FromJSON(): unexpected error `%v`", err)
+	}
+	if number := Parse("7123 4567", "ZX"); number != "99871234567" {
+		t.Errorf("Parse(number=`7123 4567`, country=`ZX`): expected `99871234567`, actual `%s`", number)
+	}
+	if number := Parse("+371 (67) 881-727", "LV"); number != "37167881727" {
+		t.Errorf("Parse(number=`+371 (67) 881-727`, country=`LV`): expected `37167881727`, actual `%s`", number)
+	}
+
+	for _, input := range []string{`[{"alpha2": "ZW", "country_code": "997"}]`, `[{"alpha2": "ZW"`, `{}`} {
+		if err := LoadISO3166FromJSON(strings.NewReader(input)); err == nil {
+			t.Errorf("LoadISO3166FromJSON(`%s`): expected error", input)
+		}
+	}
+	if country := getISO3166ByCountry("ZW"); country.CountryCode != "263" {
+		t.Errorf("LoadISO3166FromJSON(): invalid document must not be loaded, actual `%+v`", country)
+	}
+}
+
+// Countries by alpha2, alpha3 or name in any case
+var countryLookupTests = []struct {
+	country  string
+	expected string
+}{
+	{"LV", "LV"},
+	{" Us", "US"},
+	{"us ", "US"},
+	{"usa", "US"},
+	{"uk", "GB"},
+	{"GBR", "GB"},
+	{"Latvia", "LV"},
+	{"united KINGDOM", "GB"},
+	{"UnitedKingdom", "GB"},
+	{"Great Britain", "GB"},
+	{"Russia", "RU"},
+	{"russian federation", "RU"},
+	{"South Korea", "KR"},
+	{"Korea, Republic of", "KR"},
+	{"IVORY COAST", "CI"},
+	{"Türkiye", "TR"},
+	{"xx", ""},
+	{"Atlantis", ""},
+	{"", ""},
+}
+
+func TestGetISO3166ByCountry(t *testing.T) {
+	for _, tt := range countryLookupTests {
+		if country := getISO3166ByCountry(tt.country); country.Alpha2 != tt.expected {
+			t.Errorf("getISO3166ByCountry(country=`%s`): expected `%s`, actual `%s`", tt.country, tt.expected, country.Alpha2)
+		}
+	}
+}
+
+func TestISO3166IsZero(t *testing.T) {
+	if !(ISO3166{}).IsZero() {
+		t.Error("ISO3166{}.IsZero(): expected `true`, actual `false`")
+	}
+	if getISO3166ByCountry("LV").IsZero() {
+		t.Error("ISO3166(LV).IsZero(): expected `false`, actual `true`")
+	}
+	if !getISO3166ByCountry("XX").IsZero() {
+		t.Error("ISO3166(XX).IsZero(): expected `true`, actual `false`")
+	}
+	if country, _ := DetectCountry("+999999999999999"); !country.IsZero() {
+		t.Errorf("DetectCountry(number=`+999999999999999`): expected the empty country, actual `%s`", country.Alpha2)
+	}
+}
+
+func TestISO3166String(t *testing.T) {
+	tests := []struct {
+		iso3166  ISO3166
+		expected string
+	}{
+		{getISO3166ByCountry("US"), "United States (US/+1)"},
+		{getISO3166ByCountry("LV"), "Latvia (LV/+371)"},
+		{ISO3166{}, "<unknown>"},
+	}
+	for _, tt := range tests {
+		if s := tt.iso3166.String(); s != tt.expected {
+			t.Errorf("ISO3166(%s).String(): expected `%s`, actual `%s`", tt.iso3166.Alpha2, tt.expected, s)
+		}
+	}
+	if s := fmt.Sprint(getISO3166ByCountry("GB")); s != "United Kingdom (GB/+44)" {
+		t.Errorf("fmt.Sprint(ISO3166(GB)): expected `United Kingdom (GB/+44)`, actual `%s`", s)
+	}
+}
+
+func TestMobilePrefixes(t *testing.T) {
+	if prefixes := MobilePrefixes("LV"); strings.Join(prefixes, ",") != "2" {
+		t.Errorf("MobilePrefixes(country=`LV`): expected `[2]`, actual `%v`", prefixes)
+	}
+	if prefixes := MobilePrefixes("Latvia"); len(prefixes) != 1 {
+		t.Errorf("MobilePrefixes(country=`Latvia`): expected `[2]`, actual `%v`", prefixes)
+	}
+	if prefixes := MobilePrefixes("XX"); prefixes != nil {
+		t.Errorf("MobilePrefixes(country=`XX`): expected nil, actual `%v`", prefixes)
+	}
+
+	prefixes := MobilePrefixes("LV")
+	prefixes[0] = "000"
+	if MobilePrefixes("LV")[0] != "2" {
+		t.Error("MobilePrefixes(country=`LV`): changing the result must not change the shared data")
+	}
+}
+
+func TestPhoneLengths(t *testing.T) {
+	if lengths := PhoneLengths("EE"); len(lengths) != 2 || lengths[0] != 7 || lengths[1] != 8 {
+		t.Errorf("PhoneLengths(country=`EE`): expected `[7 8]`, actual `%v`", lengths)
+	}
+	if lengths := PhoneLengths("XX"); lengths != nil {
+		t.Errorf("PhoneLengths(country=`XX`): expected nil, actual `%v`", lengths)
+	}
+}
+
+func TestCountriesByRegion(t *testing.T) {
+	total := 0
+	for _, region := range []string{"Africa", "Americas", "Asia", "Europe", "Oceania"} {
+		countries := CountriesByRegion(region)
+		for k, i := range countries {
+			if i.Region != region {
+				t.Errorf("CountriesByRegion(region=`%s`): unexpected country `%s` of region `%s`", region, i.Alpha2, i.Region)
+			}
+			if k > 0 && countries[k-1].CountryName > i.CountryName {
+				t.Errorf("CountriesByRegion(region=`%s`): `%s` must be sorted before `%s`", region, i.CountryName, countries[k-1].CountryName)
+			}
+		}
+		total += len(countries)
+	}
+	expected := 0
+	for _, countries := range countryRegions {
+		expected += len(countries)
+	}
+	if total != expected {
+		t.Errorf("CountriesByRegion(): expected %d countries in all the regions, actual %d", expected, total)
+	}
+
+	if countries := CountriesByRegion("europe"); len(countries) == 0 || indexOfAlpha2("LV", countries) < 0 {
+		t.Errorf("CountriesByRegion(region=`europe`): expected LV to be found")
+	}
+	if countries := CountriesByRegion("Atlantis"); countries == nil || len(countries) != 0 {
+		t.Errorf("CountriesByRegion(region=`Atlantis`): expected an empty slice, actual `%v`", countries)
+	}
+}
+
+func TestRandomNumber(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, i := range GetISO3166() {
+		if number := RandomNumber(i.Alpha2, true, rng); number != "" && Parse(number, i.Alpha2) != number {
+			t.Errorf("RandomNumber(country=`%s`, mobile=true): `%s` must pass Parse", i.Alpha2, number)
+		}
+		if number := RandomNumber(i.Alpha2, false, rng); ParseWithLandLine(number, i.Alpha2) != number {
+			t.Errorf("RandomNumber(country=`%s`, mobile=false): `%s` must pass ParseWithLandLine", i.Alpha2, number)
+		}
+	}
+
+	for _, country := range []string{"LV", "US", "GB", "CI"} {
+		if number := RandomNumber(country, true, rng); number == "" {
+			t.Errorf("RandomNumber(country=`%s`, mobile=true): expected a number, actual ``", country)
+		}
+	}
+	if number := RandomNumber("FO", true, rng); number != "" {
+		t.Errorf("RandomNumber(country=`FO`, mobile=true): expected ``, actual `%s`", number)
+	}
+	if number := RandomNumber("XX", false, rng); number != "" {
+		t.Errorf("RandomNumber(country=`XX`, mobile=false): expected ``, actual `%s`", number)
+	}
+
+	first := RandomNumber("LV", true, rand.New(rand.NewSource(42)))
+	if second := RandomNumber("LV", true, rand.New(rand.NewSource(42))); first != second {
+		t.Errorf("RandomNumber(country=`LV`): expected the same number for the same seed, actual `%s` and `%s`", first, second)
+	}
+}
+
+func TestExampleNumber(t *testing.T) {
+	for _, i := range GetISO3166() {
+		if number := ExampleNumber(i.Alpha2, true); number != "" && Parse(number, i.Alpha2) != number {
+			t.Errorf("ExampleNumber(country=`%s`, mobile=true): `%s` must pass Parse", i.Alpha2, number)
+		}
+		if number := ExampleNumber(i.Alpha2, false); number != "" && ParseWithLandLine(number, i.Alpha2) != number {
+			t.Errorf("ExampleNumber(country=`%s`, mobile=false): `%s` must pass ParseWithLandLine", i.Alpha2, number)
+		}
+		for _, mobile := range []bool{true, false} {
+			if number := ExampleNumber(i.Alpha2, mobile); number != "" && IsLikelyFake(number, i.Alpha2) {
+				t.Errorf("ExampleNumber(country=`%s`, mobile=%t): `%s` must not be IsLikelyFake", i.Alpha2, mobile, number)
+			}
+		}
+		if first, second := ExampleNumber(i.Alpha2, true), ExampleNumber(i.Alpha2, true); first != second {
+			t.Errorf("ExampleNumber(country=`%s`, mobile=true): expected a stable number, actual `%s` and `%s`", i.Alpha2, first, second)
+		}
+	}
+
+	if number := ExampleNumber("GB", true); number != "447400123456" {
+		t.Errorf("ExampleNumber(country=`GB`, mobile=true): expected `447400123456`, actual `%s`", number)
+	}
+	if number := ExampleNumber("LV", true); number != "37121234567" {
+		t.Errorf("ExampleNumber(country=`LV`, mobile=true): expected `37121234567`, actual `%s`", number)
+	}
+	if number := ExampleNumber("LV", false); number != "37112345687" {
+		t.Errorf("ExampleNumber(country=`LV`, mobile=false): expected `37112345687`, actual `%s`", number)
+	}
+	if number := ExampleNumber("XX", false); number != "" {
+		t.Errorf("ExampleNumber(country=`XX`, mobile=false): expected ``, actual `%s`", number)
+	}
+}
+
+// FuzzParse checks the parsed numbers are always empty or E.164, whatever the input
+func FuzzParse(f *testing.F) {
+	for _, tt := range mobWithLLFormatTests {
+		f.Add(tt.input, tt.country)
+	}
+	f.Add("+0000000000000000000000", "AR")
+	f.Add("5454545454545454", "AR")
+	f.Add("00 00 371 0 25641580", "LV")
+	f.Add("+٣٧١ ٢٥٦٤١٥٨٠", "")
+
+	f.Fuzz(func(t *testing.T, number string, country string) {
+		if parsed := Parse(number, country); parsed != "" && !IsE164("+"+parsed) {
+			t.Errorf("Parse(number=`%s`, country=`%s`): expected an E.164 number, actual `%s`", number, country, parsed)
+		}
+		parsed, valid, _ := ParseWithFlags(number, country)
+		if parsed != "" && !IsE164("+"+parsed) {
+			t.Errorf("ParseWithFlags(number=`%s`, country=`%s`): expected an E.164 number, actual `%s`", number, country, parsed)
+		}
+		if valid != (parsed != "") {
+			t.Errorf("ParseWithFlags(number=`%s`, country=`%s`): expected valid `%t`, actual `%t`", number, country, parsed != "", valid)
+		}
+	})
+}
+
+func TestParseIdempotent(t *testing.T) {
+	inputs := []struct{ input, country string }{}
+	for _, tt := range mobWithLLFormatTests {
+		inputs = append(inputs, struct{ input, country string }{tt.input, tt.country})
+	}
+	// raw digits, not only the valid numbers, given nationally and internationally,
+	// some starting with the country code or the 00 international prefix
+	rng := rand.New(rand.NewSource(1))
+	for _, i := range GetISO3166() {
+		for _, length := range i.PhoneNumberLengths {
+			for k := 0; k < 60; k++ {
+				national := [][]byte{nil, []byte(i.CountryCode), []byte("00")}[k%3]
+				for len(national) < length {
+					national = append(national, byte('0'+rng.Intn(10)))
+				}
+				national = national[:length]
+				for _, input := range []string{string(national), "0" + string(national), i.CountryCode + string(national), "+" + i.CountryCode + string(national), "00" + i.CountryCode + string(national)} {
+					inputs = append(inputs, struct{ input, country string }{input, i.Alpha2})
+				}
+			}
+		}
+	}
+
+	for _, tt := range inputs {
+		for name, parse := range map[string]func(string, string) string{"Parse": Parse, "ParseWithLandLine": ParseWithLandLine} {
+			parsed := parse(tt.input, tt.country)
+			if parsed == "" {
+				continue
+			}
+			if again := parse(parsed, tt.country); again != parsed {
+				t.Errorf("%s(number=`%s`, country=`%s`): expected `%s` parsed from `%s`, actual `%s`", name, parsed, tt.country, parsed, tt.input, again)
+			}
+		}
+	}
+}
+
+// keepISO3166 restores the countries, as they were before the test, when the test is done
+func keepISO3166(t *testing.T) {
+	t.Helper()
+	GetISO3166()
+	iso3166Lock.RLock()
+	datas, trie, index := iso3166Datas, iso3166Trie, iso3166Index
+	iso3166Lock.RUnlock()
+	t.Cleanup(func() {
+		iso3166Lock.Lock()
+		iso3166Datas, iso3166Trie, iso3166Index = datas, trie, index
+		iso3166Version.Add(1)
+		iso3166Lock.Unlock()
+	})
+}
+
+func TestRegisterCountry(t *testing.T) {
+	keepISO3166(t)
+	zz := ISO3166{
+		Alpha2:             "ZZ",
+		Alpha3:             "ZZZ",
+		CountryCode:        "999",
+		CountryName:        "Test Country",
+		MobileBeginWith:    []string{"5"},
+		PhoneNumberLengths: []int{9},
+	}
+	if err := RegisterCountry(zz); err != nil {
+		t.Fatalf("RegisterCountry(%s): unexpected error `%v`", zz.Alpha2, err)
+	}
+	if err := RegisterCountry(zz); !errors.Is(err, ErrCountryExists) {
+		t.Errorf("RegisterCountry(%s): expected error `%v`, actual `%v`", zz.Alpha2, ErrCountryExists, err)
+	}
+	if err := RegisterCountry(ISO3166{Alpha2: "ZY"}); !errors.Is(err, ErrInvalidCountry) {
+		t.Errorf("RegisterCountry(ZY): expected error `%v`, actual `%v`", ErrInvalidCountry, err)
+	}
+
+	if number := Parse("0512 345 678", "ZZ"); number != "999512345678" {
+		t.Errorf("Parse(number=`0512 345 678`, country=`ZZ`): expected `999512345678`, actual `%s`", number)
+	}
+	if country, _ := DetectCountry("+999512345678"); country.Alpha2 != "ZZ" {
+		t.Errorf("DetectCountry(number=`+999512345678`): expected `ZZ`, actual `%s`", country.Alpha2)
+	}
+
+	zz.MobileBeginWith = []string{"6"}
+	if err := OverrideCountry("zz", zz); err != nil {
+		t.Fatalf("OverrideCountry(zz): unexpected error `%v`", err)
+	}
+	if number := Parse("0512 345 678", "ZZ"); number != "" {
+		t.Errorf("Parse(number=`0512 345 678`, country=`ZZ`): expected ``, actual `%s`", number)
+	}
+	if number := Parse("0612 345 678", "ZZ"); number != "999612345678" {
+		t.Errorf("Parse(number=`0612 345 678`, country=`ZZ`): expected `999612345678`, actual `%s`", number)
+	}
+	if err := OverrideCountry("ZY", zz); !errors.Is(err, ErrUnknownCountry) {
+		t.Errorf("OverrideCountry(ZY): expected error `%v`, actual `%v`", ErrUnknownCountry, err)
+	}
+}
+
+// Partially entered numbers
+var possibleNumberTests = []struct {
+	input    string
+	country  string
+	expected bool
+}{
+	{"2", "LV", true},
+	{"256", "LV", true},
+	{"25641580", "LV", true},
+	{"+371 256", "LV", true},
+	{"+371 (0) 256", "LV", true},
+	{"37125641", "LV", true},
+	{"090 61", "JP", true},
+	{"07400", "GB", true},
+	{"202555", "US", true},
+
+	// Landline prefixes
+	{"67", "LV", false},
+	{"+371 67", "LV", false},
+
+	// Too long
+	{"256415801", "LV", false},
+	{"+371 256415801", "LV", false},
+
+	// Empty and unknown
+	{"", "LV", false},
+	{"+-()", "LV", false},
+	{"256", "XXXK", false},
+	{"+371 256", "", false},
+	{"+372 256", "LV", false},
+}
+
+// State of the partially entered numbers
+var stateTests = []struct {
+	input    string
+	country  string
+	expected string
+	state    NumberState
+}{
+	{"25641580", "LV", "37125641580", Valid},
+	{"+371 25 641 580", "LV", "37125641580", Valid},
+	{"256", "LV", "", Possible},
+	{"+371 256", "LV", "", Possible},
+	{"06 20 12", "HU", "", Possible},
+	{"202555", "US", "", Possible},
+	{"256415801", "LV", "", TooLong},
+	{"+371 256415801", "LV", "", TooLong},
+	{"67", "LV", "", Invalid},
+	{"67881727", "LV", "", Invalid},
+	{"", "LV", "", Invalid},
+	{"256", "XXXK", "", Invalid},
+}
+
+func TestParseWithState(t *testing.T) {
+	for _, tt := range stateTests {
+		if number, state := ParseWithState(tt.input, tt.country); number != tt.expected || state != tt.state {
+			t.Errorf("ParseWithState(number=`%s`, country=`%s`): expected (`%s`, %d), actual (`%s`, %d)", tt.input, tt.country, tt.expected, tt.state, number, state)
+		}
+	}
+}
+
+func TestIsPossibleNumber(t *testing.T) {
+	for _, tt := range possibleNumberTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			possible := IsPossibleNumber(tt.input, tt.country)
+			if possible != tt.expected {
+				t.Errorf("IsPossibleNumber(number=`%s`, country=`%s`): expected `%t`, actual `%t`", tt.input, tt.country, tt.expected, possible)
+			}
+		})
+	}
+}
+
+// Countries where the leading zero is a significant part of the number
+var leadingZeroTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	// Côte d'Ivoire
+	{"+225 07 77 40 11 60", "CI", "2250777401160"},
+	{"225 07 77 40 11 60", "CI", "2250777401160"},
+	{"00225 07 77 40 11 60", "CI", "2250777401160"},
+	{"07 77 40 11 60", "CI", "2250777401160"},
+
+	// Gabon
+	{"+241 06 12 34 56", "GA", "24106123456"},
+	{"241 06 12 34 56", "GA", "24106123456"},
+	{"06 12 34 56", "GA", "24106123456"},
+
+	// Congo
+	{"+242 06 612 3456", "CG", "242066123456"},
+	{"242 06 612 3456", "CG", "242066123456"},
+	{"06 612 3456", "CG", "242066123456"},
+
+	// Italy
+	{"+39 339 638 066", "IT", "39339638066"},
+	{"0039 339 638 066", "IT", "39339638066"},
+	{"339 638 066", "IT", "39339638066"},
+}
+
+// Mobile numbers with the national prefix kept after the international prefix and the country code
+var trunkPrefixInMobileTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"0049 (0)151 12345678", "DE", "4915112345678"},
+	{"0049 0151 12345678", "DE", "4915112345678"},
+	{"0041 (0)79 123 45 67", "CH", "41791234567"},
+	{"0033 (0)6 12 34 56 78", "FR", "33612345678"},
+	{"0031 (0)6 12345678", "NL", "31612345678"},
+	{"0043 (0)664 1234567", "AT", "436641234567"},
+	{"0032 (0)470 12 34 56", "BE", "32470123456"},
+	// landlines are not retried
+	{"0041 (0)44 123 45 67", "CH", ""},
+	// the countries without the flag
+	{"00371 0 25 641 580", "LV", ""},
+}
+
+func TestParseTrunkPrefixInMobile(t *testing.T) {
+	for _, tt := range trunkPrefixInMobileTests {
+		if number := Parse(tt.input, tt.country); number != tt.expected {
+			t.Errorf("Parse(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+		}
+	}
+}
+
+// International numbers with the optional trunk prefix printed in parentheses
+var printedTrunkPrefixTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"+44 (0) 20 7946 0000", "GB", "442079460000"},
+	{"+44(0)7700 900000", "GB", "447700900000"},
+	{"0044 (0) 20 7946 0000", "GB", "442079460000"},
+	{"+33 (0)6 12 34 56 78", "FR", "33612345678"},
+	{"+33 (0)1 23 45 67 89", "FR", "33123456789"},
+	{"+49 (0)30 1234567", "DE", "49301234567"},
+	{"+49 (0)151 12345678", "DE", "4915112345678"},
+	{"+41 (0)44 123 45 67", "CH", "41441234567"},
+	{"+31 (0)20 123 4567", "NL", "31201234567"},
+	{"+43 (0)1 234567", "AT", "431234567"},
+	{"+32 (0)2 123 45 67", "BE", "3221234567"},
+	{"+32 (0)470 12 34 56", "BE", "32470123456"},
+	{"+353 (0)1 234 5678", "IE", "35312345678"},
+	{"+353 (0)87 123 4567", "IE", "353871234567"},
+	{"+36 (0)1 234 5678", "HU", "3612345678"},
+	{"+7 (0)495 123 45 67", "RU", "74951234567"},
+	// the zero is a part of the number in Italy
+	{"+39 (0)6 6982 1234", "IT", "390669821234"},
+}
+
+func TestParsePrintedTrunkPrefix(t *testing.T) {
+	for _, tt := range printedTrunkPrefixTests {
+		if number := ParseWithLandLine(tt.input, tt.country); number != tt.expected {
+			t.Errorf("ParseWithLandLine(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+		}
+	}
+	if number := ParseE164Only("+44 (0) 7700 900000"); number != "447700900000" {
+		t.Errorf("ParseE164Only(number=`+44 (0) 7700 900000`): expected `447700900000`, actual `%s`", number)
+	}
+}
+
+// Brazilian mobiles are parsed with the mandatory 9, whether it is given or not
+var mobileNinthDigitTests = []struct {
+	input    string
+	expected string
+}{
+	{"11 98765 4321", "5511987654321"},
+	{"11 8765 4321", "5511987654321"},
+	{"(011) 8765-4321", "5511987654321"},
+	{"+55 11 8765 4321", "5511987654321"},
+	{"+55 11 98765 4321", "5511987654321"},
+	{"5511987654321", "5511987654321"},
+	{"11 3456 7890", ""},
+	{"+55 11 3456 7890", ""},
+}
+
+func TestParseMobileNinthDigit(t *testing.T) {
+	for _, tt := range mobileNinthDigitTests {
+		if number := Parse(tt.input, "BR"); number != tt.expected {
+			t.Errorf("Parse(number=`%s`, country=`BR`): expected `%s`, actual `%s`", tt.input, tt.expected, number)
+		}
+	}
+	if number := ParseWithLandLine("11 3456 7890", "BR"); number != "551134567890" {
+		t.Errorf("ParseWithLandLine(number=`11 3456 7890`, country=`BR`): expected `551134567890`, actual `%s`", number)
+	}
+}
+
+// Argentinian mobiles are parsed to the international form with the 9, from the domestic 15 one too
+var mobileTokensTests = []struct {
+	input    string
+	expected string
+	mobile   bool
+}{
+	{"+54 9 11 1234 5678", "5491112345678", true},
+	{"0054 9 11 1234 5678", "5491112345678", true},
+	{"5491112345678", "5491112345678", true},
+	{"011 15 1234 5678", "5491112345678", true},
+	{"11 15 1234 5678", "5491112345678", true},
+	{"+54 11 15 1234 5678", "5491112345678", true},
+	{"0351 15 123 4567", "5493511234567", true},
+	{"011 4123 4567", "541141234567", false},
+	{"+54 11 4123 4567", "541141234567", false},
+	{"+54 215 249 8705", "542152498705", false},
+	{"2152498705", "542152498705", false},
+	{"542152498705", "542152498705", false},
+}
+
+func TestParseMobileTokens(t *testing.T) {
+	for _, tt := range mobileTokensTests {
+		if parsed, valid, mobile := ParseWithFlags(tt.input, "AR"); parsed != tt.expected || !valid || mobile != tt.mobile {
+			t.Errorf("ParseWithFlags(number=`%s`, country=`AR`): expected (`%s`, true, %t), actual (`%s`, %t, %t)", tt.input, tt.expected, tt.mobile, parsed, valid, mobile)
+		}
+		if parsed := ParseWithLandLine(tt.expected, "AR"); parsed != tt.expected {
+			t.Errorf("ParseWithLandLine(number=`%s`, country=`AR`): expected the parsed number unchanged, actual `%s`", tt.expected, parsed)
+		}
+		if parsed := ParseWithHint("+"+tt.expected, ""); tt.mobile && parsed != tt.expected {
+			t.Errorf("ParseWithHint(number=`+%s`, hint=``): expected `%s`, actual `%s`", tt.expected, tt.expected, parsed)
+		}
+	}
+}
+
+// Numbers in the national format, with the trunk prefix and without the country code
+var nationalFormatTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"020 7946 0000", "GB", "442079460000"},
+	{"07700 900000", "GB", "447700900000"},
+	{"+44 20 7946 0000", "GB", "442079460000"},
+	{"01 23 45 67 89", "FR", "33123456789"},
+	{"06 12 34 56 78", "FR", "33612345678"},
+	{"030 1234567", "DE", "49301234567"},
+	{"089 123456", "DE", "4989123456"},
+	{"0151 12345678", "DE", "4915112345678"},
+	{"+49 30 1234567", "DE", "49301234567"},
+	{"0491 1234567", "DE", "494911234567"},
+	{"03-1234-5678", "JP", "81312345678"},
+	{"090-6135-3368", "JP", "819061353368"},
+}
+
+func TestParseNationalFormat(t *testing.T) {
+	for _, tt := range nationalFormatTests {
+		if number := ParseWithLandLine(tt.input, tt.country); number != tt.expected {
+			t.Errorf("ParseWithLandLine(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+		}
+	}
+}
+
+// Turkish numbers with the trunk 0 before the 10-digit national number
+var turkishTests = []struct {
+	input    string
+	expected string
+	mobile   bool
+}{
+	{"0532 123 45 67", "905321234567", true},
+	{"+90 532 123 45 67", "905321234567", true},
+	{"0090 532 123 45 67", "905321234567", true},
+	{"90 532 123 45 67", "905321234567", true},
+	{"532 123 45 67", "905321234567", true},
+	{"+90 (0532) 123 45 67", "905321234567", true},
+	{"905321234567", "905321234567", true},
+	{"0212 123 45 67", "902121234567", false},
+	{"+90 212 123 45 67", "902121234567", false},
+}
+
+func TestParseTurkish(t *testing.T) {
+	for _, tt := range turkishTests {
+		parsed, valid, mobile := ParseWithFlags(tt.input, "TUR")
+		if parsed != tt.expected || !valid || mobile != tt.mobile {
+			t.Errorf("ParseWithFlags(number=`%s`, country=`TUR`): expected (`%s`, true, %t), actual (`%s`, %t, %t)", tt.input, tt.expected, tt.mobile, parsed, valid, mobile)
+		}
+	}
+}
+
+// Italian landlines keep the leading zero of the area code
+var italianLandlineTests = []struct {
+	input    string
+	expected string
+}{
+	{"06 6982 1234", "390669821234"},
+	{"+39 06 6982 1234", "390669821234"},
+	{"0039 06 6982 1234", "390669821234"},
+	{"39 06 6982 1234", "390669821234"},
+	{"02 1234 5678", "390212345678"},
+}
+
+func TestParseItalianLandline(t *testing.T) {
+	for _, tt := range italianLandlineTests {
+		if number := ParseWithLandLine(tt.input, "IT"); number != tt.expected {
+			t.Errorf("ParseWithLandLine(number=`%s`, country=`IT`): expected `%s`, actual `%s`", tt.input, tt.expected, number)
+		}
+
+		// The formatted numbers keep the zero, so they are parsed back the same
+		for _, style := range []FormatStyle{FormatE164, FormatInternational, FormatNational} {
+			formatted := Format(tt.input, "IT", style)
+			if number := ParseWithLandLine(formatted, "IT"); number != tt.expected {
+				t.Errorf("ParseWithLandLine(number=`%s`, country=`IT`): expected `%s`, actual `%s`", formatted, tt.expected, number)
+			}
+		}
+	}
+}
+
+func TestParseKeepsLeadingZero(t *testing.T) {
+	for _, tt := range leadingZeroTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			number := Parse(tt.input, tt.country)
+			if number != tt.expected {
+				t.Errorf("Parse(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, number)
+			}
+		})
+	}
+}
+
+// Countries by calling code
+var countryCodeTests = []struct {
+	input    string
+	expected []string
+}{
+	{"44", []string{"GB"}},
+	{"+371", []string{"LV"}},
+	{"+7", []string{"KZ", "RU"}},
+	{"358", []string{"AX", "FI"}},
+	{"000", []string{}},
+	{"", []string{}},
+}
+
+func TestGetISO3166ByCountryCode(t *testing.T) {
+	for _, tt := range countryCodeTests {
+		countries := GetISO3166ByCountryCode(tt.input)
+		actual := []string{}
+		for _, country := range countries {
+			actual = append(actual, country.Alpha2)
+		}
+		if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("GetISO3166ByCountryCode(code=`%s`): expected `%v`, actual `%v`", tt.input, tt.expected, actual)
+		}
+	}
+
+	nanp := GetISO3166ByCountryCode("1")
+	if len(nanp) < 2 || nanp[0].Alpha2 != "US" {
+		t.Errorf("GetISO3166ByCountryCode(code=`1`): expected United States first, actual `%v`", nanp)
+	}
+}
+
+// Compare numbers given in different formats
+var matchTests = []struct {
+	a        string
+	b        string
+	country  string
+	expected Match
+}{
+	{"+1 (202) 555-0143", "202.555.0143", "US", ExactMatch},
+	{"+371 25 641 580", "25641580", "LV", ExactMatch},
+	{"+44 20 7946 0000", "020 7946 0000", "GB", ExactMatch},
+	{"+44 20 7946 0000", "020 7946 0000", "US", ShortNSNMatch},
+	{"020 7946 0000", "+44 (0) 20 7946 0000", "US", ShortNSNMatch},
+	{"+44 20 7946 0000", "+1 207 946 0000", "US", NoMatch},
+	{"+1 (202) 555-0143", "+1 (202) 555-0144", "US", NoMatch},
+	{"", "", "US", NoMatch},
+}
+
+func TestMatchType(t *testing.T) {
+	for _, tt := range matchTests {
+		if match := MatchType(tt.a, tt.b, tt.country); match != tt.expected {
+			t.Errorf("MatchType(a=`%s`, b=`%s`, country=`%s`): expected `%d`, actual `%d`", tt.a, tt.b, tt.country, tt.expected, match)
+		}
+		if same := SameNumber(tt.a, tt.b, tt.country); same != (tt.expected == ExactMatch) {
+			t.Errorf("SameNumber(a=`%s`, b=`%s`, country=`%s`): expected `%t`, actual `%t`", tt.a, tt.b, tt.country, tt.expected == ExactMatch, same)
+		}
+	}
+}
+
+// Area codes of the numbers
+var areaCodeTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"+1 202 555 0143", "US", "202"},
+	{"(416) 555-0143", "CA", "416"},
+	{"+1 876 555 0143", "JM", "876"},
+	{"030 12345678", "DE", "30"},
+	{"0221 1234567", "DE", "221"},
+	{"020 7946 0000", "GB", "20"},
+	{"0121 496 0000", "GB", "121"},
+	{"01223 496000", "GB", "1223"},
+
+	// Mobile numbers, numbers without data and invalid numbers
+	{"07400 123456", "GB", ""},
+	{"0151 12345678", "DE", ""},
+	{"+371 (67) 881-727", "LV", ""},
+	{"+1 289 2999", "US", ""},
+}
+
+func TestGetAreaCode(t *testing.T) {
+	for _, tt := range areaCodeTests {
+		tt := tt
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			areaCode := GetAreaCode(tt.input, tt.country)
+			if areaCode != tt.expected {
+				t.Errorf("GetAreaCode(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, areaCode)
+			}
+		})
+	}
+}
+
+// Carriers of the mobile numbers
+var carrierTests = []struct {
+	input    string
+	country  string
+	expected string
+}{
+	{"+380 67 123 4567", "UA", "Kyivstar"},
+	{"050 123 4567", "UA", "Vodafone"},
+	{"+375 29 123 4567", "BY", ""},
+	{"+7 747 123 4567", "KZ", "Tele2"},
+	{"+371 25 641 580", "LV", ""},
+	{"+380 44 123 4567", "UA", ""},
+	{"+380 67 123", "UA", ""},
+}
+
+func TestGetCarrier(t *testing.T) {
+	for _, tt := range carrierTests {
+		if carrier := GetCarrier(tt.input, tt.country); carrier != tt.expected {
+			t.Errorf("GetCarrier(number=`%s`, country=`%s`): expected `%s`, actual `%s`", tt.input, tt.country, tt.expected, carrier)
+		}
+	}
+}
+
+func TestSetCarriers(t *testing.T) {
+	iso3166 := getISO3166ByCountry("LV")
+	defer SetCarriers("LV", iso3166.Carriers)
+
+	carriers := map[string]string{"2": "LMT", "25": "Tele2"}
+	if err := SetCarriers("LV", carriers); err != nil {
+		t.Fatalf("SetCarriers(alpha2=`LV`): unexpected error `%v`", err)
+	}
+	carriers["25"] = "Bite"
+	if carrier := GetCarrier("+371 25 641 580", "LV"); carrier != "Tele2" {
+		t.Errorf("GetCarrier(number=`+371 25 641 580`, country=`LV`): expected `Tele2`, actual `%s`", carrier)
+	}
+	if carrier := GetCarrier("+371 26 641 580", "LV"); carrier != "LMT" {
+		t.Errorf("GetCarrier(number=`+371 26 641 580`, country=`LV`): expected `LMT`, actual `%s`", carrier)
+	}
+	if err := SetCarriers("XX", carriers); !errors.Is(err, ErrUnknownCountry) {
+		t.Errorf("SetCarriers(alpha2=`XX`): expected error `%v`, actual `%v`", ErrUnknownCountry, err)
+	}
+}
+
+// Timezones of the numbers
+var timezoneTests = []struct {
+	input    string
+	country  string
+	expected []string
+}{
+	{"+371 25 641 580", "LV", []string{"Europe/Riga"}},
+	{"(212) 555-0143", "US", []string{"America/New_York"}},
+	{"+1 415 555 0143", "US", []string{"America/Los_Angeles"}},
+	{"+1 604 555 0143", "CA", []string{"America/Vancouver"}},
+	{"+383 4 1234999", "XK", []string{"Europe/Belgrade"}},
+	{"+1 289 2999", "US", []string{}},
+	{"", "LV", []string{}},
+}
+
+func TestGetTimezones(t *testing.T) {
+	for _, tt := range timezoneTests {
+		timezones := GetTimezones(tt.input, tt.country)
+		if timezones == nil || strings.Join(timezones, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("GetTimezones(number=`%s`, country=`%s`): expected `%v`, actual `%v`", tt.input, tt.country, tt.expected, timezones)
+		}
+	}
+
+	// The area codes without timezone data fall back to all the timezones of the country
+	if timezones := GetTimezones("+1 (605) 555-0143", "US"); len(timezones) < 2 || timezones[0] != "America/New_York" {
+		t.Errorf("GetTimezones(number=`+1 (605) 555-0143`, country=`US`): expected all US timezones, actual `%v`", timezones)
+	}
+}
+
+// Emergency numbers and short codes
+var shortNumberTests = []struct {
+	input     string
+	country   string
+	emergency bool
+	shortCode bool
+}{
+	{"911", "US", true, false},
+	{"911", "USA", true, false},
+	{"112", "LV", true, false},
+	{"112", "US", false, false},
+	{"+112", "LV", false, false},
+	{"999", "GB", true, false},
+	{"000", "AU", true, false},
+	{"12345", "US", false, true},
+	{"123-456", "US", false, true},
+	{"1234567", "US", false, false},
+	{"01234", "US", false, false},
+	{"+12345", "US", false, false},
+	{"12345", "LV", false, false},
+	{"12345", "XX", false, false},
+	{"", "US", false, false},
+}
+
+func TestShortNumbers(t *testing.T) {
+	for _, tt := range shortNumberTests {
+		if emergency := IsEmergencyNumber(tt.input, tt.country); emergency != tt.emergency {
+			t.Errorf("IsEmergencyNumber(number=`%s`, country=`%s`): expected `%t`, actual `%t`", tt.input, tt.country, tt.emergency, emergency)
+		}
+		if shortCode := IsShortCode(tt.input, tt.country); shortCode != tt.shortCode {
+			t.Errorf("IsShortCode(number=`%s`, country=`%s`): expected `%t`, actual `%t`", tt.input, tt.country, tt.shortCode, shortCode)
+		}
+	}
+}
+
+// SMS and voice capabilities
+var capabilityTests = []struct {
+	input   string
+	country string
+	sms     bool
+	voice   bool
+}{
+	{"+371 25 641 580", "LV", true, true},
+	{"+371 (67) 881-727", "LV", false, true},
+	{"07012 345678", "GB", false, true},
+	{"07700 900000", "GB", true, true},
+	{"+31 97 123 456 789", "NL", true, false},
+	{"+1 289 2999", "US", false, false},
+}
+
+func TestCapabilities(t *testing.T) {
+	for _, tt := range capabilityTests {
+		if sms, voice := Capabilities(tt.input, tt.country); sms != tt.sms || voice != tt.voice {
+			t.Errorf("Capabilities(number=`%s`, country=`%s`): expected (%t, %t), actual (%t, %t)", tt.input, tt.country, tt.sms, tt.voice, sms, voice)
+		}
+	}
+}
+
+func TestIsMobileISO3166(t *testing.T) {
+	lv := getISO3166ByCountry("LV")
+	tests := []struct {
+		number   string
+		landline bool
+		mobile   bool
+	}{
+		{"37125641580", true, true},
+		{"37167881727", true, false},
+		{"3712564158", false, false},
+		{"37225641580", false, false},
+	}
+	for _, tt := range tests {
+		if mobile := IsMobileISO3166(tt.number, lv); mobile != tt.mobile {
+			t.Errorf("IsMobileISO3166(number=`%s`, country=LV): expected `%t`, actual `%t`", tt.number, tt.mobile, mobile)
+		}
+		if landline := IsLandlineISO3166(tt.number, lv); landline != tt.landline {
+			t.Errorf("IsLandlineISO3166(number=`%s`, country=LV): expected `%t`, actual `%t`", tt.number, tt.landline, landline)
+		}
+	}
+}
+
+func TestAnyMobilePrefix(t *testing.T) {
+	custom := ISO3166{Alpha2: "ZY", CountryCode: "998", MobileBeginWith: []string{""}, PhoneNumberLengths: []int{8}}
+	if IsMobileISO3166("99812345678", custom) || !IsLandlineISO3166("99812345678", custom) {
+		t.Errorf("IsMobileISO3166(number=`99812345678`, country=ZY): the empty prefix must not match without AnyMobilePrefix")
+	}
+	custom.AnyMobilePrefix = true
+	if !IsMobileISO3166("99812345678", custom) || IsMobileISO3166("9981234567", custom) {
+		t.Errorf("IsMobileISO3166(number=`99812345678`, country=ZY): any number of a mobile length must match with AnyMobilePrefix")
+	}
+
+	if number := Parse("+52 55 1234 5678", "MX"); number != "525512345678" {
+		t.Errorf("Parse(number=`+52 55 1234 5678`, country=`MX`): expected `525512345678`, actual `%s`", number)
+	}
+	if reasons := Diagnose("+52 55 1234 5678", "MX"); len(reasons) != 0 {
+		t.Errorf("Diagnose(number=`+52 55 1234 5678`, country=`MX`): expected no reasons, actual `%v`", reasons)
+	}
+	for _, i := range GetISO3166ByMobileNumber("5512345678") {
+		if i.AnyMobilePrefix {
+			t.Errorf("GetISO3166ByMobileNumber(number=`5512345678`): unexpected `%s` with AnyMobilePrefix", i.Alpha2)
+		}
+	}
+}
+
+// Parse numbers without country
+var candidatesTests = []struct {
+	input    string
+	expected []string
+}{
+	{"+371 25 641 580", []string{"LV:37125641580"}},
+	{"+44 7700 900000", []string{"GB:447700900000"}},
+	{"+1 (204) 555-0143", []string{"CA:12045550143"}},
+	{"+371 (67) 881-727", []string{}},
+	{"", []string{}},
+}
+
+func TestParseResultRawInput(t *testing.T) {
+	raw := "  +371 25 641 580\text. 12 "
+	if result := ParseWithOptions(raw, Options{DefaultCountry: "LV", KeepExtension: true}); result.RawInput != raw {
+		t.Errorf("ParseWithOptions(number=`%s`): expected raw input `%s`, actual `%s`", raw, raw, result.RawInput)
+	}
+	if results := ParseBatchWithFlags([]string{raw, "nope"}, "LV"); results[0].RawInput != raw || results[1].RawInput != "nope" {
+		t.Errorf("ParseBatchWithFlags(numbers=[`%s`, `nope`]): expected the raw inputs, actual `%s` and `%s`", raw, results[0].RawInput, results[1].RawInput)
+	}
+	for _, result := range ParseCandidates(raw) {
+		if result.RawInput != raw {
+			t.Errorf("ParseCandidates(number=`%s`): expected raw input `%s`, actual `%s`", raw, raw, result.RawInput)
+		}
+	}
+}
+
+func TestParseCandidates(t *testing.T) {
+	for _, tt := range candidatesTests {
+		actual := []string{}
+		for _, result := range ParseCandidates(tt.input) {
+			actual = append(actual, result.ISO3166.Alpha2+":"+result.E164)
+			if !result.Valid || !result.Mobile {
+				t.Errorf("ParseCandidates(number=`%s`): candidate %s must be a valid mobile number", tt.input, result.ISO3166.Alpha2)
+			}
+		}
+		if strings.Join(actual, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("ParseCandidates(number=`%s`): expected `%v`, actual `%v`", tt.input, tt.expected, actual)
+		}
+	}
+
+	// National numbers may be valid in many countries
+	found := false
+	for _, result := range ParseCandidates("07700 900000") {
+		found = found || result.ISO3166.Alpha2 == "GB"
+	}
+	if !found {
+		t.Errorf("ParseCandidates(number=`07700 900000`): expected GB among the candidates")
+	}
+}
+
+func TestParseWithoutCountry(t *testing.T) {
+	for _, input := range []string{"(817) 569-8900", "+18175698900", "090 6135 3368"} {
+		if number := Parse(input, ""); number != "" {
+			t.Errorf("Parse(number=`%s`, country=``): expected ``, actual `%s`", input, number)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package phonenumber
+
+import "math/rand"
+
+// randomAttempts is how many numbers RandomNumber generates before giving up
+const randomAttempts = 100
+
+// RandomNumber generates a random valid number of the country, e.g. for test fixtures.
+// Mobile numbers start with one of the country MobileBeginWith prefixes and pass Parse,
+// landline numbers pass ParseWithLandLine. An empty string is returned when no valid
+// number can be generated, e.g. for unknown countries or countries without mobile prefixes.
+func RandomNumber(country string, mobile bool, rng *rand.Rand) string {
+	iso3166 := getISO3166ByCountry(country)
+	if iso3166.IsZero() {
+		return ""
+	}
+
+	lengths := fixedLineLengths(iso3166)
+	prefixes := []string{""}
+	if mobile {
+		lengths = mobileLengths(iso3166)
+		prefixes = iso3166.MobileBeginWith
+		if iso3166.AnyMobilePrefix {
+			prefixes = []string{""}
+		}
+	}
+	if len(lengths) == 0 || len(prefixes) == 0 {
+		return ""
+	}
+
+	for attempt := 0; attempt < randomAttempts; attempt++ {
+		national := []byte(prefixes[rng.Intn(len(prefixes))])
+		length := lengths[rng.Intn(len(lengths))]
+		if len(national) == 0 && length > 0 {
+			// The leading zeros are the national prefix, not a part of the number
+			national = append(national, byte('1'+rng.Intn(9)))
+		}
+		for len(national) < length {
+			national = append(national, byte('0'+rng.Intn(10)))
+		}
+
+		number := iso3166.CountryCode + string(national)
+		parsed := parseISO3166(number, iso3166)
+		if parsed != number {
+			continue
+		}
+		if mobile && validateMobileISO3166(parsed, iso3166) || !mobile && validateLandlineISO3166(parsed, iso3166) {
+			return number
+		}
+	}
+	return ""
+}
+
+// exampleNumbers contains the hand-picked example numbers by alpha2, the mobile one first
+var exampleNumbers = map[string][2]string{
+	"DE": {"4915123456789", "4930123456"},
+	"FR": {"33612345678", "33142345678"},
+	"GB": {"447400123456", "441212345678"},
+	"RU": {"79123456789", "73011234567"},
+	"US": {"12015550123", "12015550123"},
+}
+
+// ExampleNumber returns a stable valid example number of the country, e.g. for placeholders.
+// Mobile numbers pass Parse, landline numbers pass ParseWithLandLine.
+// An empty string is returned for unknown countries and when no example can be built.
+func ExampleNumber(country string, mobile bool) string {
+	iso3166 := getISO3166ByCountry(country)
+	if iso3166.IsZero() {
+		return ""
+	}
+	if example, ok := exampleNumbers[iso3166.Alpha2]; ok {
+		if mobile {
+			return example[0]
+		}
+		return example[1]
+	}
+
+	lengths := fixedLineLengths(iso3166)
+	prefixes := []string{""}
+	if mobile {
+		lengths = mobileLengths(iso3166)
+		prefixes = iso3166.MobileBeginWith
+		if iso3166.AnyMobilePrefix {
+			prefixes = []string{""}
+		}
+	}
+
+	// The digits after the prefix count up from 1, e.g. 7 1234 5678, with the last two swapped
+	// when the whole number would be a run rejected by IsLikelyFake, e.g. 1234 5687
+	for _, prefix := range prefixes {
+		for _, length := range lengths {
+			national := []byte(prefix)
+			for k := byte(1); len(national) < length; k++ {
+				national = append(national, '0'+k%10)
+			}
+			if n := len(national); isFakeDigits(string(national)) {
+				if n-len(prefix) < 2 {
+					continue
+				}
+				national[n-2], national[n-1] = national[n-1], national[n-2]
+			}
+
+			number := iso3166.CountryCode + string(national)
+			if parseISO3166(number, iso3166) != number {
+				continue
+			}
+			if mobile && validateMobileISO3166(number, iso3166) || !mobile && validateLandlineISO3166(number, iso3166) {
+				return number
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,67 @@
+package phonenumber
+
+import (
+	"sort"
+	"strings"
+)
+
+// countryRegions contains the countries by the continent they are usually grouped with.
+// The tagging is approximate, e.g. the transcontinental countries are put in a single region.
+var countryRegions = map[string][]string{
+	"Africa": {
+		"AO", "BF", "BI", "BJ", "BW", "CD", "CF", "CG", "CI", "CM", "CV", "DJ", "DZ", "EG", "ER", "ET",
+		"GA", "GH", "GM", "GN", "GQ", "GW", "KE", "KM", "LR", "LS", "LY", "MA", "MG", "ML", "MR", "MU",
+		"MW", "MZ", "NA", "NE", "NG", "RE", "RW", "SC", "SD", "SH", "SL", "SN", "SO", "ST", "SZ", "TD",
+		"TG", "TN", "TZ", "UG", "YT", "ZA", "ZM", "ZW",
+	},
+	"Americas": {
+		"AG", "AI", "AR", "AW", "BB", "BM", "BO", "BR", "BS", "BZ", "CA", "CL", "CO", "CR", "CU", "DM",
+		"DO", "EC", "FK", "GD", "GF", "GL", "GP", "GT", "GY", "HN", "HT", "JM", "KN", "KY", "LC", "MQ",
+		"MS", "MX", "NI", "PA", "PE", "PM", "PR", "PY", "SR", "SV", "SX", "TC", "TT", "US", "UY", "VC",
+		"VE", "VG", "VI",
+	},
+	"Asia": {
+		"AE", "AF", "AM", "AZ", "BD", "BH", "BN", "BT", "CN", "GE", "HK", "ID", "IL", "IN", "IQ", "IR",
+		"JO", "JP", "KG", "KH", "KR", "KW", "KZ", "LA", "LB", "LK", "MM", "MN", "MO", "MV", "MY", "NP",
+		"OM", "PH", "PK", "PS", "QA", "SA", "SG", "SY", "TH", "TJ", "TL", "TM", "TR", "TW", "UZ", "VN",
+		"YE",
+	},
+	"Europe": {
+		"AD", "AL", "AT", "AX", "BA", "BE", "BG", "BY", "CH", "CY", "CZ", "DE", "DK", "EE", "ES", "FI",
+		"FO", "FR", "GB", "GI", "GR", "HR", "HU", "IE", "IS", "IT", "LI", "LT", "LU", "LV", "MC", "MD",
+		"ME", "MK", "MT", "NL", "NO", "PL", "PT", "RO", "RS", "RU", "SE", "SI", "SJ", "SK", "SM", "UA",
+		"XK",
+	},
+	"Oceania": {
+		"AS", "AU", "CK", "FJ", "FM", "GU", "KI", "MH", "MP", "NC", "NF", "NR", "NU", "NZ", "PF", "PG",
+		"PW", "SB", "TK", "TO", "TV", "VU", "WF", "WS",
+	},
+}
+
+// populateRegions sets the regions of the countries
+func populateRegions() {
+	regions := map[string]string{}
+	for region, countries := range countryRegions {
+		for _, alpha2 := range countries {
+			regions[alpha2] = region
+		}
+	}
+	for k, i := range iso3166Datas {
+		iso3166Datas[k].Region = regions[i.Alpha2]
+	}
+}
+
+// CountriesByRegion returns the countries of the region, e.g. Europe, sorted by the country name.
+// The region is case insensitive, an empty slice is returned for unknown regions.
+func CountriesByRegion(region string) []ISO3166 {
+	result := []ISO3166{}
+	for _, i := range GetISO3166() {
+		if i.Region != "" && strings.EqualFold(i.Region, strings.TrimSpace(region)) {
+			result = append(result, i.clone())
+		}
+	}
+	sort.SliceStable(result, func(a, b int) bool {
+		return result[a].CountryName < result[b].CountryName
+	})
+	return result
+}
@@ -0,0 +1,57 @@
+package phonenumber
+
+// emergencyNumbers contains the emergency numbers by country
+var emergencyNumbers = map[string][]string{
+	"AU": {"000", "112"},
+	"BR": {"190", "192", "193"},
+	"CA": {"911"},
+	"CN": {"110", "119", "120", "122"},
+	"DE": {"110", "112"},
+	"EE": {"110", "112"},
+	"ES": {"112", "091", "092"},
+	"FR": {"112", "15", "17", "18"},
+	"GB": {"999", "112"},
+	"IN": {"100", "101", "102", "108", "112"},
+	"IT": {"112", "113", "115", "118"},
+	"JP": {"110", "118", "119"},
+	"KZ": {"112", "101", "102", "103", "104"},
+	"LT": {"112"},
+	"LV": {"112", "110", "113"},
+	"MX": {"911"},
+	"NZ": {"111"},
+	"PL": {"112", "997", "998", "999"},
+	"RU": {"112", "101", "102", "103", "104"},
+	"UA": {"112", "101", "102", "103", "104"},
+	"US": {"911"},
+}
+
+// shortCodeLengths contains the minimum and maximum length of the SMS short codes by country
+var shortCodeLengths = map[string][2]int{
+	"CA": {5, 6},
+	"DE": {4, 5},
+	"FR": {5, 5},
+	"GB": {5, 5},
+	"IN": {5, 6},
+	"RU": {4, 4},
+	"US": {5, 6},
+}
+
+// IsEmergencyNumber reports whether the number is an emergency number of the country, e.g. 112 or 911
+func IsEmergencyNumber(number string, country string) bool {
+	if isInternational(number) {
+		return false
+	}
+	iso3166 := getISO3166ByCountry(country)
+	return indexOfString(Normalize(number), emergencyNumbers[iso3166.Alpha2]) != -1
+}
+
+// IsShortCode reports whether the number has the length of the SMS short codes of the country
+func IsShortCode(number string, country string) bool {
+	if isInternational(number) {
+		return false
+	}
+	iso3166 := getISO3166ByCountry(country)
+	lengths, exists := shortCodeLengths[iso3166.Alpha2]
+	number = Normalize(number)
+	return exists && len(number) >= lengths[0] && len(number) <= lengths[1] && number[0] != '0'
+}
@@ -0,0 +1,293 @@
+package phonenumber
+
+// countryTimezones contains the IANA timezones of the countries
+var countryTimezones = map[string][]string{
+	"AD": {"Europe/Andorra"},
+	"AE": {"Asia/Dubai"},
+	"AF": {"Asia/Kabul"},
+	"AG": {"America/Antigua"},
+	"AI": {"America/Anguilla"},
+	"AL": {"Europe/Tirane"},
+	"AM": {"Asia/Yerevan"},
+	"AO": {"Africa/Luanda"},
+	"AR": {"America/Argentina/Buenos_Aires", "America/Argentina/Cordoba", "America/Argentina/Salta", "America/Argentina/Jujuy", "America/Argentina/Tucuman", "America/Argentina/Catamarca", "America/Argentina/La_Rioja", "America/Argentina/San_Juan", "America/Argentina/Mendoza", "America/Argentina/San_Luis", "America/Argentina/Rio_Gallegos", "America/Argentina/Ushuaia"},
+	"AS": {"Pacific/Pago_Pago"},
+	"AT": {"Europe/Vienna"},
+	"AU": {"Australia/Lord_Howe", "Antarctica/Macquarie", "Australia/Hobart", "Australia/Melbourne", "Australia/Sydney", "Australia/Broken_Hill", "Australia/Brisbane", "Australia/Lindeman", "Australia/Adelaide", "Australia/Darwin", "Australia/Perth", "Australia/Eucla"},
+	"AW": {"America/Aruba"},
+	"AX": {"Europe/Mariehamn"},
+	"AZ": {"Asia/Baku"},
+	"BA": {"Europe/Sarajevo"},
+	"BB": {"America/Barbados"},
+	"BD": {"Asia/Dhaka"},
+	"BE": {"Europe/Brussels"},
+	"BF": {"Africa/Ouagadougou"},
+	"BG": {"Europe/Sofia"},
+	"BH": {"Asia/Bahrain"},
+	"BI": {"Africa/Bujumbura"},
+	"BJ": {"Africa/Porto-Novo"},
+	"BM": {"Atlantic/Bermuda"},
+	"BN": {"Asia/Brunei"},
+	"BO": {"America/La_Paz"},
+	"BR": {"America/Noronha", "America/Belem", "America/Fortaleza", "America/Recife", "America/Araguaina", "America/Maceio", "America/Bahia", "America/Sao_Paulo", "America/Campo_Grande", "America/Cuiaba", "America/Santarem", "America/Porto_Velho", "America/Boa_Vista", "America/Manaus", "America/Eirunepe", "America/Rio_Branco"},
+	"BS": {"America/Nassau"},
+	"BT": {"Asia/Thimphu"},
+	"BW": {"Africa/Gaborone"},
+	"BY": {"Europe/Minsk"},
+	"BZ": {"America/Belize"},
+	"CA": {"America/St_Johns", "America/Halifax", "America/Glace_Bay", "America/Moncton", "America/Goose_Bay", "America/Blanc-Sablon", "America/Toronto", "America/Iqaluit", "America/Atikokan", "America/Winnipeg", "America/Resolute", "America/Rankin_Inlet", "America/Regina", "America/Swift_Current", "America/Edmonton", "America/Cambridge_Bay", "America/Inuvik", "America/Creston", "America/Dawson_Creek", "America/Fort_Nelson", "America/Whitehorse", "America/Dawson", "America/Vancouver"},
+	"CD": {"Africa/Kinshasa", "Africa/Lubumbashi"},
+	"CF": {"Africa/Bangui"},
+	"CG": {"Africa/Brazzaville"},
+	"CH": {"Europe/Zurich"},
+	"CI": {"Africa/Abidjan"},
+	"CK": {"Pacific/Rarotonga"},
+	"CL": {"America/Santiago", "America/Coyhaique", "America/Punta_Arenas", "Pacific/Easter"},
+	"CM": {"Africa/Douala"},
+	"CN": {"Asia/Shanghai", "Asia/Urumqi"},
+	"CO": {"America/Bogota"},
+	"CR": {"America/Costa_Rica"},
+	"CU": {"America/Havana"},
+	"CV": {"Atlantic/Cape_Verde"},
+	"CY": {"Asia/Nicosia", "Asia/Famagusta"},
+	"CZ": {"Europe/Prague"},
+	"DE": {"Europe/Berlin", "Europe/Busingen"},
+	"DJ": {"Africa/Djibouti"},
+	"DK": {"Europe/Copenhagen"},
+	"DM": {"America/Dominica"},
+	"DO": {"America/Santo_Domingo"},
+	"DZ": {"Africa/Algiers"},
+	"EC": {"America/Guayaquil", "Pacific/Galapagos"},
+	"EE": {"Europe/Tallinn"},
+	"EG": {"Africa/Cairo"},
+	"ER": {"Africa/Asmara"},
+	"ES": {"Europe/Madrid", "Africa/Ceuta", "Atlantic/Canary"},
+	"ET": {"Africa/Addis_Ababa"},
+	"FI": {"Europe/Helsinki"},
+	"FJ": {"Pacific/Fiji"},
+	"FK": {"Atlantic/Stanley"},
+	"FM": {"Pacific/Chuuk", "Pacific/Pohnpei", "Pacific/Kosrae"},
+	"FO": {"Atlantic/Faroe"},
+	"FR": {"Europe/Paris"},
+	"GA": {"Africa/Libreville"},
+	"GB": {"Europe/London"},
+	"GD": {"America/Grenada"},
+	"GE": {"Asia/Tbilisi"},
+	"GF": {"America/Cayenne"},
+	"GH": {"Africa/Accra"},
+	"GI": {"Europe/Gibraltar"},
+	"GL": {"America/Nuuk", "America/Danmarkshavn", "America/Scoresbysund", "America/Thule"},
+	"GM": {"Africa/Banjul"},
+	"GN": {"Africa/Conakry"},
+	"GP": {"America/Guadeloupe"},
+	"GQ": {"Africa/Malabo"},
+	"GR": {"Europe/Athens"},
+	"GT": {"America/Guatemala"},
+	"GU": {"Pacific/Guam"},
+	"GW": {"Africa/Bissau"},
+	"GY": {"America/Guyana"},
+	"HK": {"Asia/Hong_Kong"},
+	"HN": {"America/Tegucigalpa"},
+	"HR": {"Europe/Zagreb"},
+	"HT": {"America/Port-au-Prince"},
+	"HU": {"Europe/Budapest"},
+	"ID": {"Asia/Jakarta", "Asia/Pontianak", "Asia/Makassar", "Asia/Jayapura"},
+	"IE": {"Europe/Dublin"},
+	"IL": {"Asia/Jerusalem"},
+	"IN": {"Asia/Kolkata"},
+	"IQ": {"Asia/Baghdad"},
+	"IR": {"Asia/Tehran"},
+	"IS": {"Atlantic/Reykjavik"},
+	"IT": {"Europe/Rome"},
+	"JM": {"America/Jamaica"},
+	"JO": {"Asia/Amman"},
+	"JP": {"Asia/Tokyo"},
+	"KE": {"Africa/Nairobi"},
+	"KG": {"Asia/Bishkek"},
+	"KH": {"Asia/Phnom_Penh"},
+	"KI": {"Pacific/Tarawa", "Pacific/Kanton", "Pacific/Kiritimati"},
+	"KM": {"Indian/Comoro"},
+	"KN": {"America/St_Kitts"},
+	"KR": {"Asia/Seoul"},
+	"KW": {"Asia/Kuwait"},
+	"KY": {"America/Cayman"},
+	"KZ": {"Asia/Almaty", "Asia/Qyzylorda", "Asia/Qostanay", "Asia/Aqtobe", "Asia/Aqtau", "Asia/Atyrau", "Asia/Oral"},
+	"LA": {"Asia/Vientiane"},
+	"LB": {"Asia/Beirut"},
+	"LC": {"America/St_Lucia"},
+	"LI": {"Europe/Vaduz"},
+	"LK": {"Asia/Colombo"},
+	"LR": {"Africa/Monrovia"},
+	"LS": {"Africa/Maseru"},
+	"LT": {"Europe/Vilnius"},
+	"LU": {"Europe/Luxembourg"},
+	"LV": {"Europe/Riga"},
+	"LY": {"Africa/Tripoli"},
+	"MA": {"Africa/Casablanca"},
+	"MC": {"Europe/Monaco"},
+	"MD": {"Europe/Chisinau"},
+	"ME": {"Europe/Podgorica"},
+	"MG": {"Indian/Antananarivo"},
+	"MH": {"Pacific/Majuro", "Pacific/Kwajalein"},
+	"MK": {"Europe/Skopje"},
+	"ML": {"Africa/Bamako"},
+	"MM": {"Asia/Yangon"},
+	"MN": {"Asia/Ulaanbaatar", "Asia/Hovd"},
+	"MO": {"Asia/Macau"},
+	"MP": {"Pacific/Saipan"},
+	"MQ": {"America/Martinique"},
+	"MR": {"Africa/Nouakchott"},
+	"MS": {"America/Montserrat"},
+	"MT": {"Europe/Malta"},
+	"MU": {"Indian/Mauritius"},
+	"MV": {"Indian/Maldives"},
+	"MW": {"Africa/Blantyre"},
+	"MX": {"America/Mexico_City", "America/Cancun", "America/Merida", "America/Monterrey", "America/Matamoros", "America/Chihuahua", "America/Ciudad_Juarez", "America/Ojinaga", "America/Mazatlan", "America/Bahia_Banderas", "America/Hermosillo", "America/Tijuana"},
+	"MY": {"Asia/Kuala_Lumpur", "Asia/Kuching"},
+	"MZ": {"Africa/Maputo"},
+	"NA": {"Africa/Windhoek"},
+	"NC": {"Pacific/Noumea"},
+	"NE": {"Africa/Niamey"},
+	"NF": {"Pacific/Norfolk"},
+	"NG": {"Africa/Lagos"},
+	"NI": {"America/Managua"},
+	"NL": {"Europe/Amsterdam"},
+	"NO": {"Europe/Oslo"},
+	"NP": {"Asia/Kathmandu"},
+	"NR": {"Pacific/Nauru"},
+	"NU": {"Pacific/Niue"},
+	"NZ": {"Pacific/Auckland", "Pacific/Chatham"},
+	"OM": {"Asia/Muscat"},
+	"PA": {"America/Panama"},
+	"PE": {"America/Lima"},
+	"PF": {"Pacific/Tahiti", "Pacific/Marquesas", "Pacific/Gambier"},
+	"PG": {"Pacific/Port_Moresby", "Pacific/Bougainville"},
+	"PH": {"Asia/Manila"},
+	"PK": {"Asia/Karachi"},
+	"PL": {"Europe/Warsaw"},
+	"PM": {"America/Miquelon"},
+	"PR": {"America/Puerto_Rico"},
+	"PS": {"Asia/Gaza", "Asia/Hebron"},
+	"PT": {"Europe/Lisbon", "Atlantic/Madeira", "Atlantic/Azores"},
+	"PW": {"Pacific/Palau"},
+	"PY": {"America/Asuncion"},
+	"QA": {"Asia/Qatar"},
+	"RE": {"Indian/Reunion"},
+	"RO": {"Europe/Bucharest"},
+	"RS": {"Europe/Belgrade"},
+	"RU": {"Europe/Kaliningrad", "Europe/Moscow", "Europe/Kirov", "Europe/Volgograd", "Europe/Astrakhan", "Europe/Saratov", "Europe/Ulyanovsk", "Europe/Samara", "Asia/Yekaterinburg", "Asia/Omsk", "Asia/Novosibirsk", "Asia/Barnaul", "Asia/Tomsk", "Asia/Novokuznetsk", "Asia/Krasnoyarsk", "Asia/Irkutsk", "Asia/Chita", "Asia/Yakutsk", "Asia/Khandyga", "Asia/Vladivostok", "Asia/Ust-Nera", "Asia/Magadan", "Asia/Sakhalin", "Asia/Srednekolymsk", "Asia/Kamchatka", "Asia/Anadyr"},
+	"RW": {"Africa/Kigali"},
+	"SA": {"Asia/Riyadh"},
+	"SB": {"Pacific/Guadalcanal"},
+	"SC": {"Indian/Mahe"},
+	"SD": {"Africa/Khartoum"},
+	"SE": {"Europe/Stockholm"},
+	"SG": {"Asia/Singapore"},
+	"SH": {"Atlantic/St_Helena"},
+	"SI": {"Europe/Ljubljana"},
+	"SJ": {"Arctic/Longyearbyen"},
+	"SK": {"Europe/Bratislava"},
+	"SL": {"Africa/Freetown"},
+	"SM": {"Europe/San_Marino"},
+	"SN": {"Africa/Dakar"},
+	"SO": {"Africa/Mogadishu"},
+	"SR": {"America/Paramaribo"},
+	"ST": {"Africa/Sao_Tome"},
+	"SV": {"America/El_Salvador"},
+	"SX": {"America/Lower_Princes"},
+	"SY": {"Asia/Damascus"},
+	"SZ": {"Africa/Mbabane"},
+	"TC": {"America/Grand_Turk"},
+	"TD": {"Africa/Ndjamena"},
+	"TG": {"Africa/Lome"},
+	"TH": {"Asia/Bangkok"},
+	"TJ": {"Asia/Dushanbe"},
+	"TK": {"Pacific/Fakaofo"},
+	"TL": {"Asia/Dili"},
+	"TM": {"Asia/Ashgabat"},
+	"TN": {"Africa/Tunis"},
+	"TO": {"Pacific/Tongatapu"},
+	"TR": {"Europe/Istanbul"},
+	"TT": {"America/Port_of_Spain"},
+	"TV": {"Pacific/Funafuti"},
+	"TW": {"Asia/Taipei"},
+	"TZ": {"Africa/Dar_es_Salaam"},
+	"UA": {"Europe/Simferopol", "Europe/Kyiv"},
+	"UG": {"Africa/Kampala"},
+	"US": {"America/New_York", "America/Detroit", "America/Kentucky/Louisville", "America/Kentucky/Monticello", "America/Indiana/Indianapolis", "America/Indiana/Vincennes", "America/Indiana/Winamac", "America/Indiana/Marengo", "America/Indiana/Petersburg", "America/Indiana/Vevay", "America/Chicago", "America/Indiana/Tell_City", "America/Indiana/Knox", "America/Menominee", "America/North_Dakota/Center", "America/North_Dakota/New_Salem", "America/North_Dakota/Beulah", "America/Denver", "America/Boise", "America/Phoenix", "America/Los_Angeles", "America/Anchorage", "America/Juneau", "America/Sitka", "America/Metlakatla", "America/Yakutat", "America/Nome", "America/Adak", "Pacific/Honolulu"},
+	"UY": {"America/Montevideo"},
+	"UZ": {"Asia/Samarkand", "Asia/Tashkent"},
+	"VC": {"America/St_Vincent"},
+	"VE": {"America/Caracas"},
+	"VG": {"America/Tortola"},
+	"VI": {"America/St_Thomas"},
+	"VN": {"Asia/Ho_Chi_Minh"},
+	"VU": {"Pacific/Efate"},
+	"WF": {"Pacific/Wallis"},
+	"WS": {"Pacific/Apia"},
+	"XK": {"Europe/Belgrade"},
+	"YE": {"Asia/Aden"},
+	"YT": {"Indian/Mayotte"},
+	"ZA": {"Africa/Johannesburg"},
+	"ZM": {"Africa/Lusaka"},
+	"ZW": {"Africa/Harare"},
+}
+
+// areaCodeTimezones contains the area codes by IANA timezone, for the countries
+// where the area code narrows down the timezone
+var areaCodeTimezones = map[string]map[string][]string{
+	"US": {
+		"America/New_York": {
+			"201", "202", "203", "212", "215", "216", "267", "301", "302", "305", "404", "407", "410", "412", "413", "484",
+			"516", "518", "585", "607", "610", "617", "646", "703", "704", "716", "718", "757", "772", "786", "804", "845",
+			"856", "860", "908", "917", "919", "954", "973",
+		},
+		"America/Detroit": {"248", "313", "517", "586", "616", "734", "810"},
+		"America/Chicago": {
+			"205", "210", "214", "217", "224", "251", "256", "262", "281", "309", "312", "314", "316", "319", "334", "402",
+			"414", "417", "469", "479", "501", "504", "512", "515", "563", "573", "601", "608", "612", "615", "618", "630",
+			"651", "708", "713", "715", "773", "815", "816", "817", "832", "847", "901", "913", "918", "972",
+		},
+		"America/Denver":  {"303", "307", "385", "406", "505", "719", "720", "801", "915", "970"},
+		"America/Boise":   {"208"},
+		"America/Phoenix": {"480", "520", "602", "623", "928"},
+		"America/Los_Angeles": {
+			"206", "209", "213", "253", "310", "323", "360", "408", "415", "425", "503", "510", "530", "541", "559", "562",
+			"619", "626", "650", "661", "702", "707", "714", "760", "805", "818", "831", "858", "909", "916", "925", "949",
+			"951", "971",
+		},
+		"America/Anchorage": {"907"},
+		"Pacific/Honolulu":  {"808"},
+	},
+	"CA": {
+		"America/St_Johns": {"709"},
+		"America/Halifax":  {"782", "902"},
+		"America/Moncton":  {"506"},
+		"America/Toronto": {
+			"226", "249", "289", "343", "416", "418", "437", "438", "450", "514", "519", "581", "613", "647", "705", "819",
+			"905",
+		},
+		"America/Winnipeg":  {"204", "431"},
+		"America/Regina":    {"306", "639"},
+		"America/Edmonton":  {"403", "587", "780", "825"},
+		"America/Vancouver": {"236", "250", "604", "778"},
+	},
+}
+
+// GetTimezones parses the number by country and returns the IANA timezones of the number.
+// The timezone of the area code is returned when known, otherwise all the timezones
+// of the country. An empty slice is returned for invalid numbers.
+func GetTimezones(number string, country string) []string {
+	parsed, iso3166 := parseInternal(number, country)
+	if !validateLandlineISO3166(parsed, iso3166) {
+		return []string{}
+	}
+
+	national := nationalNumber(parsed, iso3166)
+	for timezone, areaCodes := range areaCodeTimezones[iso3166.Alpha2] {
+		if hasAnyPrefix(national, areaCodes) {
+			return []string{timezone}
+		}
+	}
+	return append([]string{}, countryTimezones[iso3166.Alpha2]...)
+}
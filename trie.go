@@ -0,0 +1,65 @@
+package phonenumber
+
+import "slices"
+
+// countryTrie is a prefix tree of the country codes, each node holds the indexes
+// of the countries in the table having the country code spelled by the path to it
+type countryTrie struct {
+	children  [10]*countryTrie
+	countries []int
+}
+
+func newCountryTrie(datas []ISO3166) *countryTrie {
+	root := &countryTrie{}
+	for k, i := range datas {
+		node := root
+		for _, c := range []byte(i.CountryCode) {
+			if c < '0' || c > '9' {
+				node = nil
+				break
+			}
+			if node.children[c-'0'] == nil {
+				node.children[c-'0'] = &countryTrie{}
+			}
+			node = node.children[c-'0']
+		}
+		if node != nil && node != root {
+			node.countries = append(node.countries, k)
+		}
+	}
+	return root
+}
+
+// lookup returns the indexes of the countries whose country code the digits start with,
+// in the table order
+func (t *countryTrie) lookup(digits string) []int {
+	var result []int
+	node := t
+	for k := 0; k < len(digits) && node != nil; k++ {
+		if digits[k] < '0' || digits[k] > '9' {
+			break
+		}
+		node = node.children[digits[k]-'0']
+		if node != nil {
+			result = append(result, node.countries...)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// getISO3166ByNumberPrefix returns the countries whose country code the digits start with,
+// in the table order
+func getISO3166ByNumberPrefix(digits string) []ISO3166 {
+	iso3166Once.Do(loadISO3166)
+	iso3166Lock.RLock()
+	datas, trie := iso3166Datas, iso3166Trie
+	iso3166Lock.RUnlock()
+
+	indexes := trie.lookup(digits)
+	result := make([]ISO3166, len(indexes))
+	for k, i := range indexes {
+		result[k] = datas[i]
+	}
+	return result
+}